@@ -1,22 +1,36 @@
 package main
 
 import (
+	"context"
+	"encoding/json"
+	"flag"
 	"fmt"
 	"os"
+	"os/exec"
 	"path/filepath"
+	"sort"
+	"strings"
 	"time"
 
 	"ctchen222/chronoflow/internal/domain"
+	"ctchen222/chronoflow/internal/habits"
 	"ctchen222/chronoflow/internal/repository"
+	"ctchen222/chronoflow/internal/secrets"
 	"ctchen222/chronoflow/internal/service"
+	"ctchen222/chronoflow/internal/sync"
+	"ctchen222/chronoflow/internal/sync/google"
 	"ctchen222/chronoflow/internal/ui"
+	"ctchen222/chronoflow/internal/ui/theme"
 	"ctchen222/chronoflow/pkg/calendar"
+	"ctchen222/chronoflow/pkg/habitpanel"
+	"ctchen222/chronoflow/pkg/login"
 	"ctchen222/chronoflow/pkg/todo"
 
 	"github.com/charmbracelet/bubbles/textarea"
 	"github.com/charmbracelet/bubbles/textinput"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
+	"golang.org/x/oauth2"
 )
 
 const appName = "chronoflow"
@@ -35,6 +49,255 @@ func getDataFilePath() string {
 	return filepath.Join(getDataDir(), "todos.json")
 }
 
+// getConfigFilePath returns the full path to the config.json file
+func getConfigFilePath() string {
+	return filepath.Join(getDataDir(), "config.json")
+}
+
+// getHabitsFilePath returns the full path to the habits.json file
+func getHabitsFilePath() string {
+	return filepath.Join(getDataDir(), "habits.json")
+}
+
+// getHabitCompletionFilePath returns the full path to the habit_completion.json file
+func getHabitCompletionFilePath() string {
+	return filepath.Join(getDataDir(), "habit_completion.json")
+}
+
+// getSearchIndexFilePath returns the full path to the search_index.json file
+func getSearchIndexFilePath() string {
+	return filepath.Join(getDataDir(), "search_index.json")
+}
+
+// getCalDAVCacheFilePath returns the full path to the offline fallback
+// cache CalDAVTodoRepository mirrors its todos to.
+func getCalDAVCacheFilePath() string {
+	return filepath.Join(getDataDir(), "caldav_cache.json")
+}
+
+// getICSFilePath returns the default iCalendar file the "I"/"E" shortcuts
+// import from and export to.
+func getICSFilePath() string {
+	return filepath.Join(getDataDir(), "chronoflow.ics")
+}
+
+// runICSCommand services the --import/--export CLI flags, round-tripping
+// the local JSON todo store through an iCalendar file without launching
+// the TUI. If both are given, import runs first so a paired --export
+// reflects the freshly imported data.
+func runICSCommand(importPath, exportPath string) error {
+	configRepo := repository.NewJSONConfigRepository(getConfigFilePath())
+	config, err := configRepo.Load()
+	if err != nil {
+		return fmt.Errorf("load config: %w", err)
+	}
+
+	repo := repository.NewJSONTodoRepository(getDataFilePath())
+	if err := repo.Load(); err != nil {
+		return fmt.Errorf("load todos: %w", err)
+	}
+
+	// Goes through TodoService (not the repository directly) so the
+	// imported todos are reindexed for search and Persist saves both the
+	// todo store and the search index, same as the in-app I/E shortcuts.
+	todoService := service.NewTodoService(repo, service.NewRealTimeProvider(), configRepo, config, getSearchIndexFilePath())
+
+	if importPath != "" {
+		f, err := os.Open(importPath)
+		if err != nil {
+			return fmt.Errorf("open %s: %w", importPath, err)
+		}
+		err = todoService.Import(f)
+		f.Close()
+		if err != nil {
+			return fmt.Errorf("import %s: %w", importPath, err)
+		}
+		if err := todoService.Persist(); err != nil {
+			return fmt.Errorf("save todos: %w", err)
+		}
+		fmt.Printf("Imported %s\n", importPath)
+	}
+
+	if exportPath != "" {
+		f, err := os.Create(exportPath)
+		if err != nil {
+			return fmt.Errorf("create %s: %w", exportPath, err)
+		}
+		err = todoService.Export(f)
+		f.Close()
+		if err != nil {
+			return fmt.Errorf("export %s: %w", exportPath, err)
+		}
+		fmt.Printf("Exported %s\n", exportPath)
+	}
+	return nil
+}
+
+// getViewsFilePath returns the full path to the saved-views file, kept
+// under ~/.config (alongside theme.toml, see internal/ui/theme.Load)
+// rather than getDataDir since it's user preference, not todo data.
+func getViewsFilePath() string {
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return filepath.Join(".", "chronoflow-views.json")
+	}
+	return filepath.Join(homeDir, ".config", appName, "views.json")
+}
+
+// syncTickInterval is how often background CalDAV sync runs while the app
+// is open.
+const syncTickInterval = 2 * time.Minute
+
+// reminderTickInterval is how often today's todos are checked for due
+// reminders (Todo.DueAt minus Todo.AlarmOffset).
+const reminderTickInterval = 20 * time.Second
+
+// reminderBannerDuration is how long a fired reminder's banner stays on
+// screen before it's cleared.
+const reminderBannerDuration = 15 * time.Second
+
+// reminderTickMsg fires every reminderTickInterval to check for due
+// reminders.
+type reminderTickMsg struct{}
+
+// reminderTickCmd schedules the next reminder check.
+func reminderTickCmd() tea.Cmd {
+	return tea.Tick(reminderTickInterval, func(time.Time) tea.Msg {
+		return reminderTickMsg{}
+	})
+}
+
+// bellCmd rings the terminal bell for a fired reminder.
+func bellCmd() tea.Cmd {
+	return func() tea.Msg {
+		fmt.Print("\a")
+		return nil
+	}
+}
+
+// notifyCmd shells out to the user-configured notify command (Config.
+// NotifyCommand, e.g. "notify-send chronoflow"), appending title as its
+// final argument. A blank command is a no-op.
+func notifyCmd(command, title string) tea.Cmd {
+	if command == "" {
+		return nil
+	}
+	parts := strings.Fields(command)
+	if len(parts) == 0 {
+		return nil
+	}
+	return func() tea.Msg {
+		exec.Command(parts[0], append(parts[1:], title)...).Run()
+		return nil
+	}
+}
+
+// caldavProfile is the internal/secrets profile name the CalDAV account's
+// credentials are stored under. Chronoflow only supports one CalDAV
+// account at a time, so a single fixed profile is enough.
+const caldavProfile = "caldav"
+
+// resolveCalDAVPassword looks up the saved CalDAV password, trying the
+// secrets store (OS keyring, or its encrypted-file fallback) first and
+// falling back to the legacy CHRONOFLOW_CALDAV_PASSWORD environment
+// variable for setups that haven't logged in through the new screen yet.
+func resolveCalDAVPassword() (string, error) {
+	if _, pass, err := secrets.Load(caldavProfile); err == nil {
+		return pass, nil
+	}
+	if pass := os.Getenv("CHRONOFLOW_CALDAV_PASSWORD"); pass != "" {
+		return pass, nil
+	}
+	return "", fmt.Errorf("no CalDAV credentials found")
+}
+
+// googleProfile is the internal/secrets profile name the Google Calendar
+// account's OAuth2 token is stored under, mirroring caldavProfile: only one
+// Google account is supported at a time, so a single fixed profile is
+// enough and no per-account Config handle is needed.
+const googleProfile = "google"
+
+// resolveGoogleToken loads the previously-stored Google OAuth2 token, for
+// constructing a google.Provider (see internal/sync/google) without
+// re-running the OAuth consent flow on every launch.
+func resolveGoogleToken() (*oauth2.Token, error) {
+	raw, err := secrets.LoadRaw(googleProfile)
+	if err != nil {
+		return nil, fmt.Errorf("no Google credentials found: %w", err)
+	}
+	var token oauth2.Token
+	if err := json.Unmarshal([]byte(raw), &token); err != nil {
+		return nil, fmt.Errorf("decode stored Google token: %w", err)
+	}
+	return &token, nil
+}
+
+// persistGoogleToken saves token for later reuse by resolveGoogleToken,
+// e.g. after the initial OAuth consent flow or after a Google sync (see
+// googleSyncCmd).
+func persistGoogleToken(token *oauth2.Token) error {
+	data, err := json.Marshal(token)
+	if err != nil {
+		return fmt.Errorf("encode Google token: %w", err)
+	}
+	return secrets.StoreRaw(googleProfile, string(data))
+}
+
+// googleSyncWindow bounds how far back and forward googleSyncCmd pulls
+// events from, centered on the moment it runs: there's no calendar-cursor
+// driven range for a background tick the way CalDAV's repository-backed
+// Pull has, so it covers a generous fixed window instead.
+const googleSyncWindow = 365 * 24 * time.Hour
+
+// buildGoogleProvider constructs a google.Provider for cfg if Google sync
+// is enabled and a token was previously stored via persistGoogleToken,
+// returning (nil, nil) when Google sync simply isn't configured (not an
+// error condition, same as CalDAV's pickerRepo/caldavRepo both staying nil
+// when Config.CalDAV.Enabled is false).
+func buildGoogleProvider(ctx context.Context, cfg domain.GoogleConfig) (*google.Provider, error) {
+	if !cfg.Enabled {
+		return nil, nil
+	}
+	token, err := resolveGoogleToken()
+	if err != nil {
+		return nil, err
+	}
+	return google.NewProvider(ctx, google.Options{Token: token, CalendarID: googleCalendarID(cfg)})
+}
+
+// googleCalendarID picks which Google calendar to sync. google.Provider
+// only syncs one calendar at a time, so when CalendarTags names more than
+// one, the
+// lexicographically first ID is used, rather than an arbitrary one that
+// would change between runs with Go's randomized map iteration order.
+// With no tagged calendar at all, "primary" (the account's default
+// calendar) is used, the same fallback google.golang.org/api/calendar/v3
+// itself uses for an unspecified calendar ID.
+func googleCalendarID(cfg domain.GoogleConfig) string {
+	if len(cfg.CalendarTags) == 0 {
+		return "primary"
+	}
+	ids := make([]string, 0, len(cfg.CalendarTags))
+	for id := range cfg.CalendarTags {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	return ids[0]
+}
+
+// bucketByDueDate groups todos by the local-date key of their DueAt, the
+// shape CalendarAdapter.BuildTodoStatus's remote parameter expects.
+// google.Provider.Pull always returns todos with a concrete DueAt (see
+// eventToTodo), so none are dropped here.
+func bucketByDueDate(todos []domain.Todo) map[string][]domain.Todo {
+	byDate := make(map[string][]domain.Todo)
+	for _, t := range todos {
+		key := t.DueAt.Format("2006-01-02")
+		byDate[key] = append(byDate[key], t)
+	}
+	return byDate
+}
+
 type model struct {
 	// Services
 	todoService     *service.TodoService
@@ -42,6 +305,35 @@ type model struct {
 	presenter       *ui.TodoPresenter
 	calendarAdapter *ui.CalendarAdapter
 	viewRenderer    *ui.ViewRenderer
+	habitService    *habits.HabitService
+	viewService     *service.ViewService
+	timeProv        service.TimeProvider
+	configRepo      repository.ConfigRepository
+	config          domain.Config
+
+	// CalDAV sync: caldavRepo is non-nil once a calendar has been chosen
+	// and two-way sync is active.
+	caldavRepo *sync.CalDAVTodoRepository
+	// calendarPicker drives the first-run "choose a calendar" modal, shown
+	// when CalDAV is enabled but Config.CalDAV.Calendar is still unset.
+	calendarPicker ui.CalendarPickerState
+	pickerRepo     *sync.CalDAVTodoRepository // connected, calendar not yet chosen
+	// login drives the first-run "connect a CalDAV account" modal, shown
+	// when CalDAV is enabled but no credentials were found in internal/secrets
+	// or CHRONOFLOW_CALDAV_PASSWORD.
+	login login.Model
+
+	// Google Calendar sync: googleProvider is non-nil once Config.Google.
+	// Enabled is set and a stored OAuth2 token was found via
+	// resolveGoogleToken. Unlike CalDAV, there's no first-run login/picker
+	// screen for it yet (that's a separate OAuth consent + calendar-picker
+	// UI, not shipped here), so a Google account can only be connected by
+	// storing a token under googleProfile some other way. googleTodos is
+	// the last-pulled snapshot, refreshed on the same interval as CalDAV's
+	// background sync and fed into CalendarAdapter.BuildTodoStatus as its
+	// read-only remote view.
+	googleProvider *google.Provider
+	googleTodos    map[string][]domain.Todo
 
 	// UI Components
 	calendar    *calendar.Model
@@ -50,67 +342,460 @@ type model struct {
 	descInput   textarea.Model
 	searchInput textinput.Model
 
+	// Habit panel state
+	habitPanel        habitpanel.Model
+	habitPanelVisible bool
+
 	// State
-	state           ui.AppState
-	focus           ui.AppFocus
-	editFocus       ui.EditFocus
-	editingIndex    int
-	editingPriority domain.Priority
-	deletingIndex   int
-	deletingTitle   string
-	searchResults   []service.SearchResult
-	searchIndex     int
+	state               ui.AppState
+	focus               ui.AppFocus
+	editFocus           ui.EditFocus
+	editingIndex        int
+	editingPriority     domain.Priority
+	deletingIndex       int
+	deletingTitle       string
+	searchResults       []service.SearchResult
+	searchIndex         int
+	searchCaseSensitive bool
+
+	// Recurrence editing state, alongside titleInput/descInput above.
+	editingRecurrencePreset ui.RecurrencePreset
+	customRRuleInput        textinput.Model
+
+	// Due-time editing state, alongside titleInput/descInput above.
+	dueTimeInput textinput.Model
+	editingAlarm ui.AlarmPreset
+	// editTarget says which TodoService call StateEditing's save should
+	// make; see its doc comment.
+	editTarget editTarget
+	// editingParentDate/editingParentIndex/editingOccurrenceDate locate the
+	// recurring todo being edited (editTargetRecurring only): parent is
+	// where the master is stored, occurrence is the date under the cursor.
+	editingParentDate     time.Time
+	editingParentIndex    int
+	editingOccurrenceDate time.Time
+	editScope             ui.EditScope
+	editScopeCursor       int
+	// scopeTarget says what StateEditScope's chosen scope applies to once
+	// selected: editing the todo (the default) or deleting an occurrence.
+	scopeTarget scopeAction
+
+	// editingSubtaskParentUID/editingSubtaskParentTitle locate the parent
+	// todo a new subtask is being added under (editTargetSubtask only).
+	editingSubtaskParentUID   string
+	editingSubtaskParentTitle string
 
 	// Preview state
 	markdownRenderer *ui.MarkdownRenderer
 	previewEnabled   bool
+
+	// collapsedParents holds the UIDs of subtask parents currently
+	// collapsed in the todo list tree view.
+	collapsedParents map[string]bool
+
+	// themePicker drives the theme-picker modal opened by the T keybind.
+	themePicker ui.ThemePickerState
+
+	// remindersFired holds the "UID@date" keys of todo occurrences whose
+	// due-time reminder has already fired this run, so reminderTickMsg
+	// doesn't re-fire it on every subsequent tick (see
+	// TodoService.DueReminders, which owns the key format and updates this
+	// map in place). reminderBanner holds the most recently fired
+	// reminders' titles, shown above the help bar until reminderBannerUntil
+	// passes.
+	remindersFired      map[string]bool
+	reminderBanner      string
+	reminderBannerUntil time.Time
+
+	// Saved-views state: views caches ViewService.GetAll() (refreshed after
+	// every mutation), activeViewUID names the view currently rendered in
+	// the todo panel (empty means showing the calendar cursor's date as
+	// usual, see updateTodos).
+	views         []domain.SavedView
+	viewsCursor   int
+	activeViewUID string
+	// viewNameInput collects the name in StateNamingView. namingViewQuery
+	// is the query being saved as a new view (namingViewUID empty); a
+	// non-empty namingViewUID means this is a rename of an existing view
+	// instead.
+	viewNameInput   textinput.Model
+	namingViewQuery string
+	namingViewUID   string
 }
 
-// updateTodos sets the items for the todo list based on the selected date.
+// updateTodos sets the items for the todo list: the active saved view's
+// results if one is active (see jumpToView), otherwise the calendar
+// cursor's date as usual.
 func (m *model) updateTodos() {
+	if m.activeViewUID != "" {
+		m.updateTodosFromActiveView()
+	} else {
+		m.updateTodosFromCursor()
+	}
+
+	// Calculate and set statistics
+	stats := m.calculateStats()
+	m.todo.SetStats(stats)
+
+	// Update calendar with dates that have todos
+	m.syncCalendarTodos()
+}
+
+func (m *model) updateTodosFromCursor() {
 	cursorDate := m.calendar.Cursor()
 
 	// Get todos with status from service
 	todosWithStatus := m.todoService.GetTodosForDate(cursorDate)
 
 	// Convert to list items using presenter
-	items := m.presenter.ToListItems(todosWithStatus)
+	items := m.presenter.ToListItems(todosWithStatus, m.collapsedParents)
 	m.todo.SetItems(items)
 	m.todo.SetTitle(fmt.Sprintf("To-Do on %s", cursorDate.Format("2006-01-02")))
+}
 
-	// Calculate and set statistics
-	stats := m.calculateStats()
-	m.todo.SetStats(stats)
+func (m *model) updateTodosFromActiveView() {
+	var view domain.SavedView
+	found := false
+	for _, sv := range m.views {
+		if sv.UID == m.activeViewUID {
+			view, found = sv, true
+			break
+		}
+	}
+	if !found {
+		m.activeViewUID = ""
+		m.updateTodosFromCursor()
+		return
+	}
 
-	// Update calendar with dates that have todos
-	m.syncCalendarTodos()
+	results := m.todoService.Search(view.Query, false)
+	todosWithStatus := m.todoService.TodosFromResults(results)
+	items := m.presenter.ToListItems(todosWithStatus, nil)
+	m.todo.SetItems(items)
+	m.todo.SetTitle("View: " + view.Name)
+}
+
+// jumpToView activates uid's saved view, rendering its results in the todo
+// panel in place of the calendar cursor's date. Jumping to the
+// already-active view deactivates it instead.
+func (m *model) jumpToView(uid string) {
+	if m.activeViewUID == uid {
+		m.activeViewUID = ""
+	} else {
+		m.activeViewUID = uid
+	}
+	m.updateTodos()
+}
+
+// refreshViews reloads the saved-views cache from ViewService, called
+// after every mutation (save, rename, pin, delete).
+func (m *model) refreshViews() {
+	m.views = m.viewService.GetAll()
+	if m.viewsCursor >= len(m.views) {
+		m.viewsCursor = len(m.views) - 1
+	}
+	if m.viewsCursor < 0 {
+		m.viewsCursor = 0
+	}
+}
+
+// applyTheme swaps the active theme across both the view renderer and the
+// todo-list presenter, then refreshes the currently rendered todo items so
+// the switch is visible immediately.
+func (m *model) applyTheme(th theme.Theme) {
+	m.viewRenderer.SetTheme(th)
+	m.presenter.SetTheme(th)
+	m.updateTodos()
 }
 
 // calculateStats calculates todo statistics based on the current view mode
 func (m *model) calculateStats() todo.Stats {
 	viewMode := ui.ConvertViewMode(m.calendar.GetViewMode())
-	svcStats := m.statsCalc.CalculateStats(m.todoService.GetAllTodos(), viewMode, m.calendar.Cursor())
+	svcStats := m.statsCalc.CalculateStatsWithHabits(m.todoService.GetAllTodos(), m.habitService, viewMode, m.calendar.Cursor())
 
 	return todo.Stats{
-		TotalAll:        svcStats.TotalAll,
-		CompletedAll:    svcStats.CompletedAll,
-		OverdueAll:      svcStats.OverdueAll,
-		TotalPeriod:     svcStats.TotalPeriod,
-		CompletedPeriod: svcStats.CompletedPeriod,
-		OverduePeriod:   svcStats.OverduePeriod,
-		PeriodLabel:     svcStats.PeriodLabel,
+		TotalAll:             svcStats.TotalAll,
+		CompletedAll:         svcStats.CompletedAll,
+		OverdueAll:           svcStats.OverdueAll,
+		TotalPeriod:          svcStats.TotalPeriod,
+		CompletedPeriod:      svcStats.CompletedPeriod,
+		OverduePeriod:        svcStats.OverduePeriod,
+		PeriodLabel:          svcStats.PeriodLabel,
+		HabitsPeriodComplete: svcStats.HabitsPeriodComplete,
 	}
 }
 
-// syncCalendarTodos updates the calendar with todo status for each date
+// syncCalendarTodos updates the calendar with todo status for each date,
+// including one marker per occurrence date of any recurring todo visible in
+// the current month/week window.
 func (m *model) syncCalendarTodos() {
-	todoStatus := m.calendarAdapter.BuildTodoStatus(m.todoService.GetAllTodos())
+	viewMode := ui.ConvertViewMode(m.calendar.GetViewMode())
+	start, end := m.statsCalc.VisibleRange(viewMode, m.calendar.Cursor())
+
+	var dirty map[string]bool
+	if m.caldavRepo != nil {
+		dirty = m.caldavRepo.PendingUIDs()
+	}
+	todoStatus := m.calendarAdapter.BuildTodoStatus(m.todoService.TodosForCalendar(start, end), dirty, m.googleTodos)
+	if m.caldavRepo != nil {
+		mergeCalDAVEvents(todoStatus, m.caldavRepo.Events())
+	}
 	m.calendar.SetTodoStatus(todoStatus)
+	m.calendar.SetSpans(m.calendarAdapter.BuildSpans(m.todoService.GetAllTodos()))
+	cursorDay := m.calendar.Cursor()
+	cursorTodos := m.todoService.TodosForCalendar(cursorDay, cursorDay.AddDate(0, 0, 1))[cursorDay.Format("2006-01-02")]
+	m.calendar.SetAgendaSummary(agendaSummary(m.statsCalc.TodayByHour(cursorTodos)))
+}
+
+// agendaSummary formats the busiest hour in byHour (as produced by
+// StatsCalculator.TodayByHour) as a short "peak HH:00 ×N" annotation for
+// agenda view's header, or "" if nothing has a due time.
+func agendaSummary(byHour map[int]int) string {
+	peakHour, peakCount := 0, 0
+	for hour, count := range byHour {
+		if count > peakCount || (count == peakCount && hour < peakHour) {
+			peakHour, peakCount = hour, count
+		}
+	}
+	if peakCount == 0 {
+		return ""
+	}
+	return fmt.Sprintf("peak %02d:00 ×%d", peakHour, peakCount)
+}
+
+// editTarget says which TodoService call the editing modal's save should
+// make.
+type editTarget int
+
+const (
+	editTargetNew editTarget = iota
+	editTargetPlain
+	editTargetRecurring
+	// editTargetSubtask creates a new todo parented under
+	// editingSubtaskParentUID (see TodoService.AddSubtask), ignoring the
+	// recurrence/due-time/alarm fields the same modal also shows, same as
+	// editTargetRecurring's per-occurrence branches do.
+	editTargetSubtask
+)
+
+// scopeAction says what StateEditScope's chosen scope (this event/this and
+// future/all events) is being applied to.
+type scopeAction int
+
+const (
+	scopeActionEdit scopeAction = iota
+	scopeActionDelete
+)
+
+// recurrenceFromPreset builds the domain.Recurrence a preset represents.
+// dtstart supplies RecurrenceWeekly's BYDAY; RecurrenceWeekdays is always
+// Monday-Friday. RecurrenceCustom parses custom as a raw
+// RRULE string instead. Returns a nil Recurrence (no error) for
+// RecurrenceNone.
+func recurrenceFromPreset(preset ui.RecurrencePreset, dtstart time.Time, custom string) (*domain.Recurrence, error) {
+	switch preset {
+	case ui.RecurrenceNone:
+		return nil, nil
+	case ui.RecurrenceDaily:
+		return &domain.Recurrence{Freq: domain.FreqDaily, Interval: 1}, nil
+	case ui.RecurrenceWeekdays:
+		return &domain.Recurrence{
+			Freq:     domain.FreqWeekly,
+			Interval: 1,
+			ByDay:    []time.Weekday{time.Monday, time.Tuesday, time.Wednesday, time.Thursday, time.Friday},
+		}, nil
+	case ui.RecurrenceWeekly:
+		return &domain.Recurrence{Freq: domain.FreqWeekly, Interval: 1, ByDay: []time.Weekday{dtstart.Weekday()}}, nil
+	case ui.RecurrenceMonthly:
+		return &domain.Recurrence{Freq: domain.FreqMonthly, Interval: 1}, nil
+	case ui.RecurrenceYearly:
+		return &domain.Recurrence{Freq: domain.FreqYearly, Interval: 1}, nil
+	case ui.RecurrenceCustom:
+		if strings.TrimSpace(custom) == "" {
+			return nil, nil
+		}
+		r, err := domain.ParseRRule(custom)
+		if err != nil {
+			return nil, err
+		}
+		return &r, nil
+	default:
+		return nil, nil
+	}
+}
+
+// dueTimeFromInput parses the editing modal's "HH:MM" due-time field,
+// anchoring it to date. An empty input is not an error: it returns the
+// zero time, meaning "no due time set".
+func dueTimeFromInput(date time.Time, hhmm string) (time.Time, error) {
+	hhmm = strings.TrimSpace(hhmm)
+	if hhmm == "" {
+		return time.Time{}, nil
+	}
+	t, err := time.ParseInLocation("15:04", hhmm, time.Local)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("due time must be HH:MM: %w", err)
+	}
+	return time.Date(date.Year(), date.Month(), date.Day(), t.Hour(), t.Minute(), 0, 0, time.Local), nil
+}
+
+// recurrencePresetFor classifies an existing Recurrence back into the
+// preset the editing modal should show it as, falling back to
+// RecurrenceCustom for any pattern that doesn't match a simple preset.
+func recurrencePresetFor(r *domain.Recurrence) ui.RecurrencePreset {
+	if r == nil {
+		return ui.RecurrenceNone
+	}
+	weekdaySet := func(days []time.Weekday) bool {
+		if len(days) != 5 {
+			return false
+		}
+		want := map[time.Weekday]bool{time.Monday: true, time.Tuesday: true, time.Wednesday: true, time.Thursday: true, time.Friday: true}
+		for _, d := range days {
+			if !want[d] {
+				return false
+			}
+		}
+		return true
+	}
+	switch {
+	case r.Freq == domain.FreqDaily && r.Interval <= 1 && r.Count == 0 && r.Until.IsZero() && len(r.ExceptionDates) == 0:
+		return ui.RecurrenceDaily
+	case r.Freq == domain.FreqWeekly && r.Interval <= 1 && r.Count == 0 && r.Until.IsZero() && len(r.ExceptionDates) == 0 && weekdaySet(r.ByDay):
+		return ui.RecurrenceWeekdays
+	case r.Freq == domain.FreqWeekly && r.Interval <= 1 && r.Count == 0 && r.Until.IsZero() && len(r.ExceptionDates) == 0 && len(r.ByDay) <= 1:
+		return ui.RecurrenceWeekly
+	case r.Freq == domain.FreqMonthly && r.Interval <= 1 && r.Count == 0 && r.Until.IsZero() && len(r.ExceptionDates) == 0:
+		return ui.RecurrenceMonthly
+	case r.Freq == domain.FreqYearly && r.Interval <= 1 && r.Count == 0 && r.Until.IsZero() && len(r.ExceptionDates) == 0:
+		return ui.RecurrenceYearly
+	default:
+		return ui.RecurrenceCustom
+	}
+}
+
+// alarmPresetFor classifies an existing AlarmOffset back into the preset
+// the editing modal should show it as, rounding down to the nearest preset
+// at or below it (e.g. an imported 20m offset shows as 10m) rather than
+// falling back to a "custom" option, since there's no raw-duration input.
+func alarmPresetFor(offset time.Duration) ui.AlarmPreset {
+	best := ui.AlarmNone
+	for _, p := range []ui.AlarmPreset{ui.Alarm5Min, ui.Alarm10Min, ui.Alarm30Min, ui.Alarm1Hour} {
+		if p.Offset() <= offset {
+			best = p
+		}
+	}
+	return best
+}
+
+// timelineHourRange converts a domain.TimelineConfig's "HH:MM" day bounds to
+// the hour-of-day range pkg/calendar's agenda view expects, reporting ok =
+// false if either bound fails to parse (agenda view then falls back to its
+// own default of the full day).
+func timelineHourRange(tc domain.TimelineConfig) (start, end int, ok bool) {
+	startT, err := time.Parse("15:04", tc.DayStart)
+	if err != nil {
+		return 0, 0, false
+	}
+	endT, err := time.Parse("15:04", tc.DayEnd)
+	if err != nil {
+		return 0, 0, false
+	}
+	return startT.Hour(), endT.Hour(), true
+}
+
+// mergeCalDAVEvents appends each remote calendar event as a read-only
+// TodoItem on its date, alongside local todos, so the week view shows
+// both without a separate widget.
+func mergeCalDAVEvents(todoStatus map[string]calendar.TodoStatus, events map[string][]sync.Event) {
+	for dateKey, evs := range events {
+		status := todoStatus[dateKey]
+		for _, e := range evs {
+			status.Items = append(status.Items, calendar.TodoItem{Title: e.Title, ReadOnly: true})
+		}
+		status.HasTodos = status.HasTodos || len(evs) > 0
+		status.Count += len(evs)
+		todoStatus[dateKey] = status
+	}
+}
+
+// importICS reads the VCALENDAR document at path and adds its VTODOs to
+// the todo list.
+func (m *model) importICS(path string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	return m.todoService.Import(f)
+}
+
+// exportICS writes every todo as a VCALENDAR document to path.
+func (m *model) exportICS(path string) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	return m.todoService.Export(f)
+}
+
+// exportVisibleICS writes only the todos in the calendar's currently visible
+// month/week range as a VCALENDAR document to path, using the same range
+// syncCalendarTodos computes for painting the calendar's status markers.
+func (m *model) exportVisibleICS(path string) error {
+	viewMode := ui.ConvertViewMode(m.calendar.GetViewMode())
+	start, end := m.statsCalc.VisibleRange(viewMode, m.calendar.Cursor())
+	// VisibleRange's end is exclusive (see its doc comment), but
+	// ExportRange's to is inclusive, so back up one day to avoid pulling
+	// in the first day of the next period.
+	end = end.AddDate(0, 0, -1)
+
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	return m.todoService.ExportRange(f, start, end)
+}
+
+// updateHabitPanel rebuilds the habit panel's groups from today's habits.
+func (m *model) updateHabitPanel() {
+	today := m.timeProv.Today()
+	habitsToday := m.habitService.HabitsForDate(today)
+
+	var order []domain.HabitType
+	seen := make(map[domain.HabitType]bool)
+	rows := make(map[domain.HabitType][]habitpanel.Row)
+	minutes := make(map[domain.HabitType]int)
+
+	for _, h := range habitsToday {
+		if !seen[h.Type] {
+			seen[h.Type] = true
+			order = append(order, h.Type)
+		}
+		rows[h.Type] = append(rows[h.Type], habitpanel.Row{
+			Habit:  h,
+			Done:   m.habitService.IsDone(h.UID, today),
+			Streak: m.habitService.Streak(h.UID),
+		})
+		minutes[h.Type] += h.EstimatedMinutes
+	}
+
+	groups := make([]habitpanel.Group, 0, len(order))
+	for _, t := range order {
+		groups = append(groups, habitpanel.Group{
+			Type:         t,
+			Rows:         rows[t],
+			TotalMinutes: minutes[t],
+		})
+	}
+	m.habitPanel.SetGroups(groups)
 }
 
 // performSearch searches all todos for the given query
 func (m *model) performSearch(query string) {
-	m.searchResults = m.todoService.Search(query)
+	m.searchResults = m.todoService.Search(query, m.searchCaseSensitive)
 	m.searchIndex = 0
 }
 
@@ -132,10 +817,139 @@ func (m *model) jumpToSearchResult() {
 	m.focus = ui.FocusTodo
 }
 
+// syncResultMsg reports the outcome of a background CalDAV sync.
+type syncResultMsg struct {
+	err      error
+	syncedAt time.Time
+}
+
+// syncTickMsg fires every syncTickInterval to trigger another sync.
+type syncTickMsg struct{}
+
+// calendarsLoadedMsg carries the result of listing calendars for the
+// first-run CalDAV picker.
+type calendarsLoadedMsg struct {
+	choices []ui.CalendarChoice
+	err     error
+}
+
+// syncCmd pulls remote changes and pushes pending local ones, returning a
+// syncResultMsg with the combined outcome.
+func syncCmd(repo *sync.CalDAVTodoRepository) tea.Cmd {
+	return func() tea.Msg {
+		ctx := context.Background()
+		err := repo.Pull(ctx)
+		if pushErr := repo.Push(ctx); err == nil {
+			err = pushErr
+		}
+		return syncResultMsg{err: err, syncedAt: time.Now()}
+	}
+}
+
+// syncTickCmd schedules the next background sync.
+func syncTickCmd() tea.Cmd {
+	return tea.Tick(syncTickInterval, func(time.Time) tea.Msg {
+		return syncTickMsg{}
+	})
+}
+
+// googleSyncResultMsg reports the outcome of a background Google Calendar
+// pull.
+type googleSyncResultMsg struct {
+	todos map[string][]domain.Todo
+	err   error
+}
+
+// googleSyncTickMsg fires every syncTickInterval to trigger another
+// Google Calendar pull, alongside CalDAV's own tick.
+type googleSyncTickMsg struct{}
+
+// googleSyncCmd pulls provider's events for the googleSyncWindow around
+// now and buckets them for CalendarAdapter.BuildTodoStatus. Google sync is
+// pull-only here: nothing in the TUI yet edits a todo that originated from
+// a remote Google event, so there are no local changes to push back. On
+// success it also persists provider's current token via
+// persistGoogleToken; google.Provider.Token does not refresh the token, so
+// this writes back the token that was loaded.
+func googleSyncCmd(provider *google.Provider) tea.Cmd {
+	return func() tea.Msg {
+		now := time.Now()
+		todos, err := provider.Pull(context.Background(), now.Add(-googleSyncWindow), now.Add(googleSyncWindow))
+		if err != nil {
+			return googleSyncResultMsg{err: err}
+		}
+		if token, err := provider.Token(); err == nil {
+			persistGoogleToken(token)
+		}
+		return googleSyncResultMsg{todos: bucketByDueDate(todos)}
+	}
+}
+
+// googleSyncTickCmd schedules the next background Google Calendar pull.
+func googleSyncTickCmd() tea.Cmd {
+	return tea.Tick(syncTickInterval, func(time.Time) tea.Msg {
+		return googleSyncTickMsg{}
+	})
+}
+
+// listCalendarsCmd discovers the calendars available on repo's account for
+// the first-run picker.
+func listCalendarsCmd(repo *sync.CalDAVTodoRepository) tea.Cmd {
+	return func() tea.Msg {
+		calendars, err := repo.ListCalendars(context.Background())
+		if err != nil {
+			return calendarsLoadedMsg{err: err}
+		}
+		choices := make([]ui.CalendarChoice, len(calendars))
+		for i, c := range calendars {
+			name := c.Name
+			if name == "" {
+				name = c.Path
+			}
+			choices[i] = ui.CalendarChoice{Path: c.Path, DisplayName: name}
+		}
+		return calendarsLoadedMsg{choices: choices}
+	}
+}
+
+// loginResultMsg reports the outcome of connecting to the CalDAV server
+// with the credentials entered on the first-run login screen.
+type loginResultMsg struct {
+	repo     *sync.CalDAVTodoRepository
+	url      string
+	username string
+	password string
+	err      error
+}
+
+// connectCalDAVCmd connects to the CalDAV server at url with the given
+// credentials, for the first-run login screen.
+func connectCalDAVCmd(url, username, password string) tea.Cmd {
+	return func() tea.Msg {
+		repo, err := sync.NewCalDAVTodoRepository(context.Background(), sync.Options{
+			URL:      url,
+			Username: username,
+			Password: password,
+		}, getCalDAVCacheFilePath())
+		return loginResultMsg{repo: repo, url: url, username: username, password: password, err: err}
+	}
+}
+
 func (m *model) Init() tea.Cmd {
 	m.syncCalendarTodos()
 	m.updateTodos()
-	return m.calendar.Init()
+
+	cmds := []tea.Cmd{m.calendar.Init(), reminderTickCmd()}
+	if m.state == ui.StateSelectingCalendar && m.pickerRepo != nil {
+		cmds = append(cmds, listCalendarsCmd(m.pickerRepo))
+	}
+	if m.caldavRepo != nil {
+		cmds = append(cmds, syncTickCmd())
+	}
+	if m.googleProvider != nil {
+		cmds = append(cmds, googleSyncCmd(m.googleProvider), googleSyncTickCmd())
+	}
+	return tea.Batch(cmds...)
 }
 
 func (m *model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
@@ -143,6 +957,77 @@ func (m *model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	var cmd tea.Cmd
 
 	switch msg := msg.(type) {
+	case calendarsLoadedMsg:
+		if msg.err != nil {
+			m.calendarPicker.Err = msg.err.Error()
+		} else {
+			m.calendarPicker = ui.CalendarPickerState{Choices: msg.choices}
+		}
+		return m, nil
+
+	case loginResultMsg:
+		if msg.err != nil {
+			m.login.SetError(msg.err.Error())
+			return m, nil
+		}
+		if err := secrets.Store(caldavProfile, msg.username, msg.password); err != nil {
+			m.login.SetError(fmt.Sprintf("connected, but failed to save credentials: %v", err))
+			return m, nil
+		}
+		m.config.CalDAV.URL = msg.url
+		m.config.CalDAV.Username = msg.username
+		m.configRepo.Save(m.config)
+		m.pickerRepo = msg.repo
+		m.state = ui.StateSelectingCalendar
+		return m, listCalendarsCmd(msg.repo)
+
+	case syncResultMsg:
+		if msg.err != nil {
+			m.calendar.SetSyncStatus("⚠ sync: " + msg.err.Error())
+		} else {
+			m.calendar.SetSyncStatus("✓ synced " + msg.syncedAt.Format("15:04"))
+		}
+		m.updateTodos()
+		return m, nil
+
+	case syncTickMsg:
+		return m, tea.Batch(syncCmd(m.caldavRepo), syncTickCmd())
+
+	case googleSyncResultMsg:
+		if msg.err != nil {
+			m.calendar.SetSyncStatus("⚠ Google sync: " + msg.err.Error())
+			return m, nil
+		}
+		m.googleTodos = msg.todos
+		m.syncCalendarTodos()
+		return m, nil
+
+	case googleSyncTickMsg:
+		return m, tea.Batch(googleSyncCmd(m.googleProvider), googleSyncTickCmd())
+
+	case reminderTickMsg:
+		now := time.Now()
+		today := m.timeProv.Today()
+		// A todo due tomorrow can still have a reminder time that falls
+		// before tonight's midnight (e.g. an AlarmOffset longer than the
+		// time left today), so scan tomorrow's occurrences too rather than
+		// only today's.
+		due := m.todoService.DueReminders(today, now, m.remindersFired)
+		due = append(due, m.todoService.DueReminders(today.AddDate(0, 0, 1), now, m.remindersFired)...)
+		batch := []tea.Cmd{reminderTickCmd()}
+		if len(due) > 0 {
+			titles := make([]string, len(due))
+			for i, td := range due {
+				titles[i] = td.Title
+			}
+			m.reminderBanner = strings.Join(titles, ", ")
+			m.reminderBannerUntil = now.Add(reminderBannerDuration)
+		}
+		for _, td := range due {
+			batch = append(batch, bellCmd(), notifyCmd(m.config.NotifyCommand, td.Title))
+		}
+		return m, tea.Batch(batch...)
+
 	case tea.WindowSizeMsg:
 		m.viewRenderer.SetSize(msg.Width, msg.Height)
 
@@ -173,23 +1058,97 @@ func (m *model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 	case tea.KeyMsg:
 		switch m.state {
+		case ui.StateLoggingIn:
+			switch msg.String() {
+			case "esc":
+				m.state = ui.StateViewing
+				return m, nil
+			case "enter":
+				if !m.login.Ready() {
+					m.login.SetError("all fields are required")
+					return m, nil
+				}
+				m.login.SetError("")
+				url, username, password := m.login.Values()
+				return m, connectCalDAVCmd(url, username, password)
+			case "ctrl+c":
+				return m, tea.Quit
+			}
+			var cmd tea.Cmd
+			m.login, cmd = m.login.Update(msg)
+			return m, cmd
+
+		case ui.StateSelectingCalendar:
+			switch msg.String() {
+			case "up", "k":
+				if m.calendarPicker.Cursor > 0 {
+					m.calendarPicker.Cursor--
+				}
+			case "down", "j":
+				if m.calendarPicker.Cursor < len(m.calendarPicker.Choices)-1 {
+					m.calendarPicker.Cursor++
+				}
+			case "enter":
+				if m.calendarPicker.Cursor < len(m.calendarPicker.Choices) {
+					m.config.CalDAV.Calendar = m.calendarPicker.Choices[m.calendarPicker.Cursor].Path
+					m.configRepo.Save(m.config)
+				}
+				return m, tea.Quit
+			case "q", "ctrl+c":
+				return m, tea.Quit
+			}
+			return m, nil
+
 		case ui.StateViewing:
 			switch msg.String() {
 			case "q", "ctrl+c":
 				m.todoService.Persist()
+				m.habitService.Persist()
 				return m, tea.Quit
+			case "S":
+				if m.caldavRepo != nil {
+					m.calendar.SetSyncStatus("⟳ syncing…")
+					return m, syncCmd(m.caldavRepo)
+				}
+			case "I":
+				if err := m.importICS(getICSFilePath()); err != nil {
+					m.calendar.SetSyncStatus("⚠ import: " + err.Error())
+				} else {
+					m.calendar.SetSyncStatus("✓ imported " + getICSFilePath())
+					m.updateTodos()
+				}
+			case "E":
+				if err := m.exportICS(getICSFilePath()); err != nil {
+					m.calendar.SetSyncStatus("⚠ export: " + err.Error())
+				} else {
+					m.calendar.SetSyncStatus("✓ exported " + getICSFilePath())
+				}
+			case "V":
+				if err := m.exportVisibleICS(getICSFilePath()); err != nil {
+					m.calendar.SetSyncStatus("⚠ export: " + err.Error())
+				} else {
+					m.calendar.SetSyncStatus("✓ exported visible range to " + getICSFilePath())
+				}
 			case "a":
 				m.state = ui.StateEditing
+				m.editTarget = editTargetNew
 				m.editingIndex = -1
 				m.editingPriority = domain.PriorityNone
 				m.titleInput.Reset()
 				m.descInput.Reset()
+				m.editingRecurrencePreset = ui.RecurrenceNone
+				m.customRRuleInput.Reset()
+				m.dueTimeInput.Reset()
+				m.editingAlarm = ui.AlarmNone
 				m.editFocus = ui.FocusTitle
 				return m, m.titleInput.Focus()
 			case "tab":
-				if m.focus == ui.FocusCalendar {
+				switch m.focus {
+				case ui.FocusCalendar:
 					m.focus = ui.FocusTodo
-				} else {
+				case ui.FocusTodo:
+					m.focus = ui.FocusViews
+				default:
 					m.focus = ui.FocusCalendar
 				}
 			case "/":
@@ -197,6 +1156,7 @@ func (m *model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				m.searchInput.Reset()
 				m.searchResults = nil
 				m.searchIndex = 0
+				m.searchCaseSensitive = false
 				return m, m.searchInput.Focus()
 			case "w":
 				// Toggle week/month view and refresh stats
@@ -205,6 +1165,43 @@ func (m *model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 					m.updateTodos()
 					return m, nil
 				}
+			case "H":
+				// Toggle the habit panel
+				m.habitPanelVisible = !m.habitPanelVisible
+				if m.habitPanelVisible {
+					m.updateHabitPanel()
+				}
+				return m, nil
+			case "T":
+				choices := theme.All()
+				cursor := 0
+				current := m.viewRenderer.Theme()
+				for i, th := range choices {
+					if th.Name == current.Name {
+						cursor = i
+						break
+					}
+				}
+				m.themePicker = ui.ThemePickerState{Choices: choices, Cursor: cursor, Previous: current}
+				m.state = ui.StateSelectingTheme
+				return m, nil
+			}
+
+			if m.habitPanelVisible {
+				switch msg.String() {
+				case "esc":
+					m.habitPanelVisible = false
+					return m, nil
+				case "up", "k", "down", "j":
+					m.habitPanel, cmd = m.habitPanel.Update(msg)
+					return m, cmd
+				case "enter", " ", "x":
+					if h, ok := m.habitPanel.Selected(); ok {
+						m.habitService.ToggleDone(h.UID, m.timeProv.Today())
+						m.updateHabitPanel()
+					}
+					return m, nil
+				}
 			}
 
 			switch m.focus {
@@ -217,12 +1214,29 @@ func (m *model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				case "e", "enter":
 					selected := m.todo.SelectedItem()
 					if selected != nil {
+						selectedItem := selected.(ui.TodoItem)
+						if selectedItem.Todo.Recurrence != nil {
+							m.editingParentDate = selectedItem.ParentDate
+							m.editingParentIndex = selectedItem.ParentIndex
+							m.editingOccurrenceDate = m.calendar.Cursor()
+							m.scopeTarget = scopeActionEdit
+							m.editScopeCursor = 0
+							m.state = ui.StateEditScope
+							return m, nil
+						}
 						m.state = ui.StateEditing
+						m.editTarget = editTargetPlain
 						m.editingIndex = m.todo.ListIndex()
-						selectedItem := selected.(ui.TodoItem)
 						m.titleInput.SetValue(selectedItem.Todo.Title)
 						m.descInput.SetValue(selectedItem.Desc)
 						m.editingPriority = selectedItem.Priority
+						m.editingRecurrencePreset = ui.RecurrenceNone
+						m.customRRuleInput.Reset()
+						m.dueTimeInput.Reset()
+						if selectedItem.Todo.HasDueTime() {
+							m.dueTimeInput.SetValue(selectedItem.Todo.DueAt.Format("15:04"))
+						}
+						m.editingAlarm = alarmPresetFor(selectedItem.Todo.AlarmOffset)
 						m.editFocus = ui.FocusTitle
 						return m, m.titleInput.Focus()
 					}
@@ -230,18 +1244,33 @@ func (m *model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 					m.focus = ui.FocusCalendar
 				case " ", "x":
 					// Toggle completion status
-					if m.todo.SelectedItem() != nil {
+					if selected := m.todo.SelectedItem(); selected != nil {
 						cursorDate := m.calendar.Cursor()
-						idx := m.todo.ListIndex()
-						m.todoService.ToggleComplete(cursorDate, idx)
+						selectedItem := selected.(ui.TodoItem)
+						if selectedItem.Todo.Recurrence != nil {
+							m.todoService.ToggleOccurrence(selectedItem.ParentDate, selectedItem.ParentIndex, cursorDate)
+						} else {
+							m.todoService.ToggleComplete(cursorDate, m.todo.ListIndex())
+						}
 						m.updateTodos()
 					}
 				case "d", "backspace":
 					selectedItem := m.todo.SelectedItem()
 					if selectedItem != nil {
+						item := selectedItem.(ui.TodoItem)
+						if item.Todo.Recurrence != nil {
+							m.editingParentDate = item.ParentDate
+							m.editingParentIndex = item.ParentIndex
+							m.editingOccurrenceDate = m.calendar.Cursor()
+							m.scopeTarget = scopeActionDelete
+							m.editScopeCursor = 0
+							m.state = ui.StateEditScope
+							return m, nil
+						}
+						m.scopeTarget = scopeActionEdit
 						m.state = ui.StateConfirmingDelete
 						m.deletingIndex = m.todo.ListIndex()
-						m.deletingTitle = selectedItem.(ui.TodoItem).Todo.Title
+						m.deletingTitle = item.Todo.Title
 					}
 				case "1", "2", "3", "0":
 					// Quick priority change
@@ -277,6 +1306,88 @@ func (m *model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 							m.todo, _ = m.todo.Update(tea.KeyMsg{Type: tea.KeyUp})
 						}
 					}
+				case "s":
+					// Cycle the sort mode for the current date
+					cursorDate := m.calendar.Cursor()
+					next := (m.todoService.SortModeFor(cursorDate) + 1) % 4
+					m.todoService.SortBy(cursorDate, next)
+					m.updateTodos()
+				case "h", "l":
+					// Collapse/expand a subtask parent's children
+					if selected, ok := m.todo.SelectedItem().(ui.TodoItem); ok && selected.HasChildren {
+						m.collapsedParents[selected.UID] = msg.String() == "h"
+						m.updateTodos()
+					}
+				case ">", "<":
+					// Demote/promote the selected todo's indentation level
+					if selected, ok := m.todo.SelectedItem().(ui.TodoItem); ok && !selected.IsInstance {
+						cursorDate := m.calendar.Cursor()
+						if msg.String() == ">" {
+							m.todoService.DemoteTodo(cursorDate, selected.ParentIndex)
+						} else {
+							m.todoService.PromoteTodo(cursorDate, selected.ParentIndex)
+						}
+						m.updateTodos()
+					}
+				case "n":
+					// Add a subtask under the selected todo
+					if selected, ok := m.todo.SelectedItem().(ui.TodoItem); ok && !selected.IsInstance {
+						m.state = ui.StateEditing
+						m.editTarget = editTargetSubtask
+						m.editingIndex = -1
+						m.editingSubtaskParentUID = selected.Todo.UID
+						m.editingSubtaskParentTitle = selected.Todo.Title
+						m.editingPriority = domain.PriorityNone
+						m.titleInput.Reset()
+						m.descInput.Reset()
+						m.editingRecurrencePreset = ui.RecurrenceNone
+						m.customRRuleInput.Reset()
+						m.dueTimeInput.Reset()
+						m.editingAlarm = ui.AlarmNone
+						m.editFocus = ui.FocusTitle
+						return m, m.titleInput.Focus()
+					}
+				}
+			case ui.FocusViews:
+				switch msg.String() {
+				case "j", "down":
+					if m.viewsCursor < len(m.views)-1 {
+						m.viewsCursor++
+					}
+				case "k", "up":
+					if m.viewsCursor > 0 {
+						m.viewsCursor--
+					}
+				case "enter":
+					if m.viewsCursor < len(m.views) {
+						m.jumpToView(m.views[m.viewsCursor].UID)
+					}
+				case "r":
+					if m.viewsCursor < len(m.views) {
+						selected := m.views[m.viewsCursor]
+						m.namingViewUID = selected.UID
+						m.namingViewQuery = selected.Query
+						m.viewNameInput.SetValue(selected.Name)
+						m.state = ui.StateNamingView
+						return m, m.viewNameInput.Focus()
+					}
+				case "p":
+					if m.viewsCursor < len(m.views) {
+						m.viewService.TogglePinned(m.views[m.viewsCursor].UID)
+						m.refreshViews()
+					}
+				case "d":
+					if m.viewsCursor < len(m.views) {
+						deleted := m.views[m.viewsCursor]
+						m.viewService.Delete(deleted.UID)
+						if m.activeViewUID == deleted.UID {
+							m.activeViewUID = ""
+							m.updateTodos()
+						}
+						m.refreshViews()
+					}
+				case "esc":
+					m.focus = ui.FocusCalendar
 				}
 			}
 
@@ -284,17 +1395,86 @@ func (m *model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			switch msg.String() {
 			case "y", "Y", "enter":
 				// Confirm delete
-				cursorDate := m.calendar.Cursor()
-				m.todoService.Delete(cursorDate, m.deletingIndex)
+				if m.scopeTarget == scopeActionDelete {
+					switch m.editScope {
+					case ui.ScopeThisEvent:
+						m.todoService.DeleteOccurrence(m.editingParentDate, m.editingParentIndex, m.editingOccurrenceDate)
+					case ui.ScopeThisAndFuture:
+						m.todoService.TruncateSeries(m.editingParentDate, m.editingParentIndex, m.editingOccurrenceDate)
+					default: // ScopeAllEvents
+						m.todoService.Delete(m.editingParentDate, m.editingParentIndex)
+					}
+					m.scopeTarget = scopeActionEdit
+				} else {
+					cursorDate := m.calendar.Cursor()
+					m.todoService.Delete(cursorDate, m.deletingIndex)
+				}
 				m.updateTodos()
 				m.state = ui.StateViewing
 				return m, nil
 			case "n", "N", "esc":
 				// Cancel delete
+				m.scopeTarget = scopeActionEdit
 				m.state = ui.StateViewing
 				return m, nil
 			}
 
+		case ui.StateEditScope:
+			switch msg.String() {
+			case "esc":
+				m.scopeTarget = scopeActionEdit
+				m.state = ui.StateViewing
+				return m, nil
+			case "up", "k":
+				if m.editScopeCursor > 0 {
+					m.editScopeCursor--
+				}
+				return m, nil
+			case "down", "j":
+				if m.editScopeCursor < len(ui.EditScopeChoices)-1 {
+					m.editScopeCursor++
+				}
+				return m, nil
+			case "enter":
+				switch m.editScopeCursor {
+				case 0:
+					m.editScope = ui.ScopeThisEvent
+				case 1:
+					m.editScope = ui.ScopeThisAndFuture
+				default:
+					m.editScope = ui.ScopeAllEvents
+				}
+
+				if m.scopeTarget == scopeActionDelete {
+					// Still requires a y/n confirmation, same as a plain
+					// todo's delete, before DeleteOccurrence/TruncateSeries/
+					// Delete actually runs (see StateConfirmingDelete).
+					selected, _ := m.todo.SelectedItem().(ui.TodoItem)
+					m.deletingTitle = selected.Todo.Title
+					m.state = ui.StateConfirmingDelete
+					return m, nil
+				}
+
+				selected, _ := m.todo.SelectedItem().(ui.TodoItem)
+				m.state = ui.StateEditing
+				m.editTarget = editTargetRecurring
+				m.titleInput.SetValue(selected.Todo.Title)
+				m.descInput.SetValue(selected.Desc)
+				m.editingPriority = selected.Priority
+				m.editingRecurrencePreset = recurrencePresetFor(selected.Todo.Recurrence)
+				m.customRRuleInput.Reset()
+				if selected.Todo.Recurrence != nil {
+					m.customRRuleInput.SetValue(selected.Todo.Recurrence.String())
+				}
+				m.dueTimeInput.Reset()
+				if selected.Todo.HasDueTime() {
+					m.dueTimeInput.SetValue(selected.Todo.DueAt.Format("15:04"))
+				}
+				m.editingAlarm = alarmPresetFor(selected.Todo.AlarmOffset)
+				m.editFocus = ui.FocusTitle
+				return m, m.titleInput.Focus()
+			}
+
 		case ui.StateEditing:
 			switch msg.String() {
 			case "esc":
@@ -325,14 +1505,31 @@ func (m *model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			case "ctrl+0":
 				m.editingPriority = domain.PriorityNone
 				return m, nil
+			case "ctrl+r":
+				m.editingRecurrencePreset = m.editingRecurrencePreset.Next()
+				return m, nil
+			case "ctrl+a":
+				m.editingAlarm = m.editingAlarm.Next()
+				return m, nil
 			case "tab":
-				if m.editFocus == ui.FocusTitle {
+				switch {
+				case m.editFocus == ui.FocusTitle:
 					m.editFocus = ui.FocusDesc
 					m.titleInput.Blur()
 					cmd = m.descInput.Focus()
-				} else {
+				case m.editFocus == ui.FocusDesc:
+					m.editFocus = ui.FocusDueTime
+					m.descInput.Blur()
+					cmd = m.dueTimeInput.Focus()
+				case m.editFocus == ui.FocusDueTime && m.editingRecurrencePreset == ui.RecurrenceCustom:
+					m.editFocus = ui.FocusRecurrence
+					m.dueTimeInput.Blur()
+					cmd = m.customRRuleInput.Focus()
+				default:
 					m.editFocus = ui.FocusTitle
 					m.descInput.Blur()
+					m.dueTimeInput.Blur()
+					m.customRRuleInput.Blur()
 					cmd = m.titleInput.Focus()
 				}
 				cmds = append(cmds, cmd)
@@ -343,18 +1540,44 @@ func (m *model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 					cursorDate := m.calendar.Cursor()
 					title := m.titleInput.Value()
 					desc := m.descInput.Value()
+					dtstart := cursorDate
+					if m.editTarget == editTargetRecurring {
+						dtstart = m.editingParentDate
+					}
+					recurrence, err := recurrenceFromPreset(m.editingRecurrencePreset, dtstart, m.customRRuleInput.Value())
+					if err != nil {
+						m.calendar.SetSyncStatus("⚠ recurrence: " + err.Error())
+						return m, nil
+					}
+					dueAt, err := dueTimeFromInput(cursorDate, m.dueTimeInput.Value())
+					if err != nil {
+						m.calendar.SetSyncStatus("⚠ " + err.Error())
+						return m, nil
+					}
 
-					if m.editingIndex == -1 {
-						// Add new todo
-						m.todoService.Add(cursorDate, title, desc, m.editingPriority)
-					} else {
-						// Update existing todo
-						m.todoService.Update(cursorDate, m.editingIndex, title, desc, m.editingPriority)
+					switch m.editTarget {
+					case editTargetNew:
+						m.todoService.Add(cursorDate, title, desc, m.editingPriority, recurrence, dueAt, m.editingAlarm.Offset())
+					case editTargetSubtask:
+						m.todoService.AddSubtask(cursorDate, m.editingSubtaskParentUID, title, desc, m.editingPriority)
+					case editTargetPlain:
+						m.todoService.Update(cursorDate, m.editingIndex, title, desc, m.editingPriority, recurrence, dueAt, m.editingAlarm.Offset())
+					case editTargetRecurring:
+						switch m.editScope {
+						case ui.ScopeThisEvent:
+							m.todoService.UpdateOccurrence(m.editingParentDate, m.editingParentIndex, m.editingOccurrenceDate, title, desc, m.editingPriority)
+						case ui.ScopeThisAndFuture:
+							m.todoService.SplitSeries(m.editingParentDate, m.editingParentIndex, m.editingOccurrenceDate, title, desc, m.editingPriority)
+						default: // ScopeAllEvents
+							m.todoService.Update(m.editingParentDate, m.editingParentIndex, title, desc, m.editingPriority, recurrence, dueAt, m.editingAlarm.Offset())
+						}
 					}
 					m.updateTodos()
 					m.state = ui.StateViewing
 					m.titleInput.Blur()
 					m.descInput.Blur()
+					m.dueTimeInput.Blur()
+					m.customRRuleInput.Blur()
 					return m, nil
 				}
 			}
@@ -385,12 +1608,75 @@ func (m *model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 					m.searchIndex++
 				}
 				return m, nil
+			case "ctrl+f":
+				// Toggle case-sensitive matching and re-run the search
+				m.searchCaseSensitive = !m.searchCaseSensitive
+				m.performSearch(m.searchInput.Value())
+				return m, nil
+			case "ctrl+s":
+				// Save the current query as a new saved view
+				if query := m.searchInput.Value(); query != "" {
+					m.namingViewUID = ""
+					m.namingViewQuery = query
+					m.viewNameInput.Reset()
+					m.state = ui.StateNamingView
+					m.searchInput.Blur()
+					return m, m.viewNameInput.Focus()
+				}
+				return m, nil
 			default:
 				// Update search input and perform search
 				m.searchInput, cmd = m.searchInput.Update(msg)
 				m.performSearch(m.searchInput.Value())
 				return m, cmd
 			}
+
+		case ui.StateSelectingTheme:
+			switch msg.String() {
+			case "esc":
+				m.applyTheme(m.themePicker.Previous)
+				m.state = ui.StateViewing
+				return m, nil
+			case "up", "k":
+				if m.themePicker.Cursor > 0 {
+					m.themePicker.Cursor--
+				}
+				m.applyTheme(m.themePicker.Choices[m.themePicker.Cursor])
+				return m, nil
+			case "down", "j":
+				if m.themePicker.Cursor < len(m.themePicker.Choices)-1 {
+					m.themePicker.Cursor++
+				}
+				m.applyTheme(m.themePicker.Choices[m.themePicker.Cursor])
+				return m, nil
+			case "enter":
+				m.state = ui.StateViewing
+				return m, nil
+			}
+
+		case ui.StateNamingView:
+			switch msg.String() {
+			case "esc":
+				m.state = ui.StateViewing
+				m.viewNameInput.Blur()
+				return m, nil
+			case "enter":
+				name := m.viewNameInput.Value()
+				if name != "" {
+					if m.namingViewUID == "" {
+						m.viewService.Save(name, m.namingViewQuery)
+					} else {
+						m.viewService.Rename(m.namingViewUID, name)
+					}
+					m.refreshViews()
+				}
+				m.state = ui.StateViewing
+				m.viewNameInput.Blur()
+				return m, nil
+			default:
+				m.viewNameInput, cmd = m.viewNameInput.Update(msg)
+				return m, cmd
+			}
 		}
 	}
 
@@ -398,18 +1684,24 @@ func (m *model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	prevCursor := m.calendar.Cursor()
 	switch m.state {
 	case ui.StateViewing:
-		if m.focus == ui.FocusCalendar {
+		switch m.focus {
+		case ui.FocusCalendar:
 			var newCal tea.Model
 			newCal, cmd = m.calendar.Update(msg)
 			m.calendar = newCal.(*calendar.Model)
-		} else {
+		case ui.FocusTodo:
 			m.todo, cmd = m.todo.Update(msg)
 		}
 	case ui.StateEditing:
-		if m.editFocus == ui.FocusTitle {
+		switch m.editFocus {
+		case ui.FocusTitle:
 			m.titleInput, cmd = m.titleInput.Update(msg)
-		} else {
+		case ui.FocusDesc:
 			m.descInput, cmd = m.descInput.Update(msg)
+		case ui.FocusDueTime:
+			m.dueTimeInput, cmd = m.dueTimeInput.Update(msg)
+		case ui.FocusRecurrence:
+			m.customRRuleInput, cmd = m.customRRuleInput.Update(msg)
 		}
 	case ui.StateSearching:
 		m.searchInput, cmd = m.searchInput.Update(msg)
@@ -418,6 +1710,8 @@ func (m *model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	cmds = append(cmds, cmd)
 
 	if m.state == ui.StateViewing && m.focus == ui.FocusCalendar && !prevCursor.Equal(m.calendar.Cursor()) {
+		// Navigating to a new date means leaving any active saved view.
+		m.activeViewUID = ""
 		m.updateTodos()
 	}
 
@@ -429,13 +1723,29 @@ func (m *model) View() string {
 	helpBar := m.viewRenderer.RenderHelpBar(m.state, m.focus)
 
 	switch m.state {
+	case ui.StateLoggingIn:
+		content = m.viewRenderer.RenderLogin(m.login.View())
+
+	case ui.StateSelectingCalendar:
+		content = m.viewRenderer.RenderCalendarPicker(m.calendarPicker)
+
 	case ui.StateViewing:
 		mainState := ui.MainViewState{
-			CalendarView: m.calendar.View(),
-			TodoView:     m.todo.View(),
-			Focus:        m.focus,
+			CalendarView:  m.calendar.View(),
+			TodoView:      m.todo.View(),
+			Focus:         m.focus,
+			Views:         m.views,
+			ViewsCursor:   m.viewsCursor,
+			ActiveViewUID: m.activeViewUID,
 		}
 		content = m.viewRenderer.RenderMain(mainState)
+		if m.habitPanelVisible {
+			panelStyle := lipgloss.NewStyle().
+				Border(lipgloss.RoundedBorder()).
+				BorderForeground(lipgloss.Color(m.viewRenderer.Theme().Accent)).
+				Padding(0, 1)
+			content = lipgloss.JoinVertical(lipgloss.Left, content, panelStyle.Render(m.habitPanel.View()))
+		}
 
 	case ui.StateEditing:
 		// Render markdown preview from description
@@ -444,55 +1754,179 @@ func (m *model) View() string {
 			previewContent = m.markdownRenderer.Render(m.descInput.Value())
 		}
 
+		subtaskOf := ""
+		if m.editTarget == editTargetSubtask {
+			subtaskOf = m.editingSubtaskParentTitle
+		}
 		editState := ui.EditingState{
-			IsNew:          m.editingIndex == -1,
-			Date:           m.calendar.Cursor(),
-			TitleValue:     m.titleInput.Value(),
-			DescValue:      m.descInput.Value(),
-			Priority:       m.editingPriority,
-			Focus:          m.editFocus,
-			TitleView:      m.titleInput.View(),
-			DescView:       m.descInput.View(),
-			PreviewEnabled: m.previewEnabled,
-			PreviewContent: previewContent,
+			IsNew:            m.editTarget == editTargetNew || m.editTarget == editTargetSubtask,
+			Date:             m.calendar.Cursor(),
+			SubtaskOf:        subtaskOf,
+			TitleValue:       m.titleInput.Value(),
+			DescValue:        m.descInput.Value(),
+			Priority:         m.editingPriority,
+			Focus:            m.editFocus,
+			TitleView:        m.titleInput.View(),
+			DescView:         m.descInput.View(),
+			PreviewEnabled:   m.previewEnabled,
+			PreviewContent:   previewContent,
+			RecurrencePreset: m.editingRecurrencePreset,
+			CustomRRuleView:  m.customRRuleInput.View(),
+			DueTimeValue:     m.dueTimeInput.Value(),
+			DueTimeView:      m.dueTimeInput.View(),
+			AlarmPreset:      m.editingAlarm,
 		}
 		content = m.viewRenderer.RenderEditing(editState)
 
+	case ui.StateEditScope:
+		selected, _ := m.todo.SelectedItem().(ui.TodoItem)
+		content = m.viewRenderer.RenderEditScope(ui.EditScopeState{
+			Title:    selected.Todo.Title,
+			Cursor:   m.editScopeCursor,
+			IsDelete: m.scopeTarget == scopeActionDelete,
+		})
+
 	case ui.StateConfirmingDelete:
 		deleteState := ui.DeleteState{
 			Title: m.deletingTitle,
 		}
+		if m.scopeTarget == scopeActionDelete {
+			deleteState.Scope = ui.EditScopeChoices[m.editScopeCursor]
+		}
 		content = m.viewRenderer.RenderConfirmDelete(deleteState)
 
 	case ui.StateSearching:
 		searchState := ui.SearchState{
-			InputView:   m.searchInput.View(),
-			InputValue:  m.searchInput.Value(),
-			Results:     m.searchResults,
-			SelectedIdx: m.searchIndex,
+			InputView:     m.searchInput.View(),
+			InputValue:    m.searchInput.Value(),
+			Results:       m.searchResults,
+			SelectedIdx:   m.searchIndex,
+			CaseSensitive: m.searchCaseSensitive,
 		}
 		content = m.viewRenderer.RenderSearching(searchState)
 
+	case ui.StateSelectingTheme:
+		content = m.viewRenderer.RenderThemePicker(m.themePicker)
+
+	case ui.StateNamingView:
+		content = m.viewRenderer.RenderNameView(ui.NameViewState{
+			Query:     m.namingViewQuery,
+			InputView: m.viewNameInput.View(),
+		})
+
 	default:
 		return "unknown state"
 	}
 
-	return lipgloss.JoinVertical(lipgloss.Left, content, helpBar)
+	rows := []string{content}
+	if m.reminderBanner != "" && time.Now().Before(m.reminderBannerUntil) {
+		rows = append(rows, m.viewRenderer.RenderReminderBanner(m.reminderBanner))
+	}
+	rows = append(rows, helpBar)
+	return lipgloss.JoinVertical(lipgloss.Left, rows...)
 }
 
 func main() {
+	importPath := flag.String("import", "", "import todos from an iCalendar (.ics) file and exit")
+	exportPath := flag.String("export", "", "export todos to an iCalendar (.ics) file and exit")
+	flag.Parse()
+
+	if *importPath != "" || *exportPath != "" {
+		if err := runICSCommand(*importPath, *exportPath); err != nil {
+			fmt.Printf("Error: %v\n", err)
+			os.Exit(1)
+		}
+		return
+	}
+
 	// Initialize dependencies
-	repo := repository.NewJSONTodoRepository(getDataFilePath())
+	configRepo := repository.NewJSONConfigRepository(getConfigFilePath())
+	config, err := configRepo.Load()
+	if err != nil {
+		fmt.Printf("Error loading config: %v\n", err)
+	}
+
+	var repo repository.TodoRepository
+	var caldavRepo *sync.CalDAVTodoRepository
+	var pickerRepo *sync.CalDAVTodoRepository
+	var loginModel login.Model
+	initialState := ui.StateViewing
+
+	password, passErr := resolveCalDAVPassword()
+
+	switch {
+	case config.CalDAV.Enabled && passErr != nil:
+		loginModel = login.New(config.CalDAV.URL, config.CalDAV.Username)
+		initialState = ui.StateLoggingIn
+		repo = repository.NewJSONTodoRepository(getDataFilePath())
+
+	case config.CalDAV.Enabled && config.CalDAV.Calendar != "":
+		r, err := sync.NewCalDAVTodoRepository(context.Background(), sync.Options{
+			URL:      config.CalDAV.URL,
+			Username: config.CalDAV.Username,
+			Password: password,
+			Calendar: config.CalDAV.Calendar,
+		}, getCalDAVCacheFilePath())
+		if err != nil {
+			fmt.Printf("Error connecting to CalDAV server: %v\n", err)
+			repo = repository.NewJSONTodoRepository(getDataFilePath())
+		} else {
+			caldavRepo = r
+			repo = r
+		}
+
+	case config.CalDAV.Enabled:
+		r, err := sync.NewCalDAVTodoRepository(context.Background(), sync.Options{
+			URL:      config.CalDAV.URL,
+			Username: config.CalDAV.Username,
+			Password: password,
+		}, getCalDAVCacheFilePath())
+		if err != nil {
+			fmt.Printf("Error connecting to CalDAV server: %v\n", err)
+			repo = repository.NewJSONTodoRepository(getDataFilePath())
+		} else {
+			pickerRepo = r
+			repo = repository.NewJSONTodoRepository(getDataFilePath())
+			initialState = ui.StateSelectingCalendar
+		}
+
+	default:
+		repo = repository.NewJSONTodoRepository(getDataFilePath())
+	}
+
 	if err := repo.Load(); err != nil {
 		fmt.Printf("Error loading todos: %v\n", err)
 	}
 
+	habitRepo := repository.NewJSONHabitRepository(getHabitsFilePath())
+	if err := habitRepo.Load(); err != nil {
+		fmt.Printf("Error loading habits: %v\n", err)
+	}
+	habitCompletionRepo := repository.NewJSONHabitCompletionRepository(getHabitCompletionFilePath())
+	if err := habitCompletionRepo.Load(); err != nil {
+		fmt.Printf("Error loading habit completion history: %v\n", err)
+	}
+
+	viewRepo := repository.NewJSONViewRepository(getViewsFilePath())
+	if err := viewRepo.Load(); err != nil {
+		fmt.Printf("Error loading saved views: %v\n", err)
+	}
+
+	googleProvider, err := buildGoogleProvider(context.Background(), config.Google)
+	if err != nil {
+		fmt.Printf("Error connecting to Google Calendar: %v\n", err)
+	}
+
 	timeProv := service.NewRealTimeProvider()
 	statsCalc := service.NewStatsCalculator(timeProv)
-	todoService := service.NewTodoService(repo, timeProv)
-	presenter := ui.NewTodoPresenter()
+	statsCalc.SetLeafOnly(true) // todos use subtasks; don't double-count parent + children
+	todoService := service.NewTodoService(repo, timeProv, configRepo, config, getSearchIndexFilePath())
+	habitService := habits.NewHabitService(habitRepo, habitCompletionRepo, timeProv)
+	viewService := service.NewViewService(viewRepo)
+	activeTheme := theme.Load()
+	presenter := ui.NewTodoPresenter(activeTheme)
 	calendarAdapter := ui.NewCalendarAdapter(statsCalc)
-	viewRenderer := ui.NewViewRenderer()
+	viewRenderer := ui.NewViewRenderer(activeTheme)
 
 	// Initialize Title Input
 	ti := textinput.New()
@@ -514,6 +1948,25 @@ func main() {
 	si.CharLimit = 100
 	si.Width = 38
 
+	// Initialize custom RRULE Input, used only when RecurrenceCustom is
+	// selected in the editing modal
+	ri := textinput.New()
+	ri.Placeholder = "FREQ=WEEKLY;BYDAY=MO,WE"
+	ri.CharLimit = 256
+	ri.Width = 56
+
+	// Initialize saved-view naming Input, used in StateNamingView
+	vi := textinput.New()
+	vi.Placeholder = "View name..."
+	vi.CharLimit = 64
+	vi.Width = 38
+
+	// Initialize due-time Input, used in the editing modal
+	di := textinput.New()
+	di.Placeholder = "14:30"
+	di.CharLimit = 5
+	di.Width = 8
+
 	// Initialize Markdown Renderer
 	mdRenderer := ui.NewMarkdownRenderer(40) // Initial width, will be resized
 
@@ -524,24 +1977,47 @@ func main() {
 		presenter:       presenter,
 		calendarAdapter: calendarAdapter,
 		viewRenderer:    viewRenderer,
+		habitService:    habitService,
+		viewService:     viewService,
+		timeProv:        timeProv,
+		configRepo:      configRepo,
+		config:          config,
+
+		caldavRepo: caldavRepo,
+		pickerRepo: pickerRepo,
+		login:      loginModel,
+
+		googleProvider: googleProvider,
 
 		// UI Components
-		calendar:    calendar.New(),
-		todo:        todo.New(),
-		titleInput:  ti,
-		descInput:   ta,
-		searchInput: si,
+		calendar:         calendar.New(),
+		todo:             todo.New(),
+		titleInput:       ti,
+		descInput:        ta,
+		searchInput:      si,
+		customRRuleInput: ri,
+		dueTimeInput:     di,
+		viewNameInput:    vi,
+		habitPanel:       habitpanel.New(),
 
 		// State
-		state:        ui.StateViewing,
+		state:        initialState,
 		focus:        ui.FocusCalendar,
 		editingIndex: -1,
+		editTarget:   editTargetNew,
 		editFocus:    ui.FocusTitle,
 
 		// Preview
 		markdownRenderer: mdRenderer,
 		previewEnabled:   true, // Preview enabled by default
+
+		collapsedParents: make(map[string]bool),
+		remindersFired:   make(map[string]bool),
+	}
+	if start, end, ok := timelineHourRange(config.Timeline); ok {
+		m.calendar.SetAgendaRange(start, end)
 	}
+	m.refreshViews()
 	p := tea.NewProgram(m, tea.WithAltScreen())
 	if _, err := p.Run(); err != nil {
 		fmt.Printf("Alas, there's been an error: %v", err)
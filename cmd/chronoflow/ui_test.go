@@ -6,10 +6,13 @@ import (
 	"time"
 
 	"ctchen222/chronoflow/internal/domain"
+	"ctchen222/chronoflow/internal/habits"
 	"ctchen222/chronoflow/internal/repository"
 	"ctchen222/chronoflow/internal/service"
 	"ctchen222/chronoflow/internal/ui"
+	"ctchen222/chronoflow/internal/ui/theme"
 	"ctchen222/chronoflow/pkg/calendar"
+	"ctchen222/chronoflow/pkg/habitpanel"
 	"ctchen222/chronoflow/pkg/todo"
 
 	"github.com/charmbracelet/bubbles/textarea"
@@ -50,11 +53,20 @@ func newTestModel(t *testing.T) *model {
 		Priority: domain.PriorityLow,
 	})
 
+	configRepo := repository.NewJSONConfigRepository(t.TempDir() + "/test_config.json")
 	statsCalc := service.NewStatsCalculator(timeProv)
-	todoService := service.NewTodoService(repo, timeProv)
-	presenter := ui.NewTodoPresenter()
+	todoService := service.NewTodoService(repo, timeProv, configRepo, domain.DefaultConfig(), t.TempDir()+"/test_search_index.json")
+
+	habitRepo := repository.NewJSONHabitRepository(t.TempDir() + "/test_habits.json")
+	habitCompletionRepo := repository.NewJSONHabitCompletionRepository(t.TempDir() + "/test_habit_completion.json")
+	habitService := habits.NewHabitService(habitRepo, habitCompletionRepo, timeProv)
+
+	viewRepo := repository.NewJSONViewRepository(t.TempDir() + "/test_views.json")
+	viewService := service.NewViewService(viewRepo)
+
+	presenter := ui.NewTodoPresenter(theme.Default())
 	calendarAdapter := ui.NewCalendarAdapter(statsCalc)
-	viewRenderer := ui.NewViewRenderer()
+	viewRenderer := ui.NewViewRenderer(theme.Default())
 
 	// Initialize inputs
 	ti := textinput.New()
@@ -72,6 +84,11 @@ func newTestModel(t *testing.T) *model {
 	si.CharLimit = 100
 	si.Width = 38
 
+	vi := textinput.New()
+	vi.Placeholder = "View name..."
+	vi.CharLimit = 64
+	vi.Width = 38
+
 	mdRenderer := ui.NewMarkdownRenderer(40)
 
 	// Create calendar and set to fixed date
@@ -84,17 +101,23 @@ func newTestModel(t *testing.T) *model {
 		presenter:        presenter,
 		calendarAdapter:  calendarAdapter,
 		viewRenderer:     viewRenderer,
+		habitService:     habitService,
+		viewService:      viewService,
+		timeProv:         timeProv,
 		calendar:         cal,
 		todo:             todo.New(),
 		titleInput:       ti,
 		descInput:        ta,
 		searchInput:      si,
+		viewNameInput:    vi,
+		habitPanel:       habitpanel.New(),
 		state:            ui.StateViewing,
 		focus:            ui.FocusCalendar,
 		editingIndex:     -1,
 		editFocus:        ui.FocusTitle,
 		markdownRenderer: mdRenderer,
 		previewEnabled:   true,
+		collapsedParents: make(map[string]bool),
 	}
 
 	return m
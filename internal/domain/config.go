@@ -18,9 +18,44 @@ func DefaultTimelineConfig() TimelineConfig {
 	}
 }
 
+// CalDAVConfig holds the settings needed to reach a remote CalDAV server.
+// The account password is intentionally not stored here: it is resolved at
+// startup from internal/secrets (OS keyring, falling back to an encrypted
+// local file), or from the legacy CHRONOFLOW_CALDAV_PASSWORD environment
+// variable if no secret has been saved yet.
+type CalDAVConfig struct {
+	Enabled  bool   `json:"enabled"`
+	URL      string `json:"url"`
+	Username string `json:"username"`
+	Calendar string `json:"calendar"` // calendar path chosen on first run
+}
+
+// GoogleConfig holds the settings needed to reach Google Calendar as a
+// second, optional sync provider alongside CalDAV. The OAuth2 token is
+// intentionally not stored here, for the same reason CalDAVConfig omits
+// the account password: it lives in internal/secrets instead.
+type GoogleConfig struct {
+	Enabled bool `json:"enabled"`
+	// CalendarTags maps a Google calendar ID to a color/tag, set in the
+	// config file. Only its keys are read, to choose which calendar to sync
+	// (see googleCalendarID in cmd/chronoflow); the tag values are not used
+	// when displaying events.
+	CalendarTags map[string]string `json:"calendar_tags,omitempty"`
+}
+
 // Config holds all user configuration
 type Config struct {
 	Timeline TimelineConfig `json:"timeline"`
+	CalDAV   CalDAVConfig   `json:"caldav"`
+	Google   GoogleConfig   `json:"google"`
+	// SortModes holds the chosen todo sort mode per date key ("2006-01-02"),
+	// as produced by service.SortMode.String(). A date with no entry sorts
+	// manually.
+	SortModes map[string]string `json:"sort_modes,omitempty"`
+	// NotifyCommand, if set, is run (with the due todo's title as its sole
+	// extra argument) whenever a reminder fires, alongside the built-in
+	// terminal bell and banner, e.g. "notify-send chronoflow".
+	NotifyCommand string `json:"notify_command,omitempty"`
 }
 
 // DefaultConfig returns the default configuration
@@ -0,0 +1,64 @@
+package domain
+
+import "time"
+
+// HabitType buckets a habit for display, grouping it alongside similar
+// habits in the habit panel.
+type HabitType int
+
+const (
+	HabitMorning HabitType = iota
+	HabitEvening
+	HabitDaily
+	HabitWeekly
+)
+
+// String returns the string representation of the habit type.
+func (t HabitType) String() string {
+	switch t {
+	case HabitMorning:
+		return "Morning"
+	case HabitEvening:
+		return "Evening"
+	case HabitWeekly:
+		return "Weekly"
+	default:
+		return "Daily"
+	}
+}
+
+// Habit represents a recurring habit tracked independently of one-off
+// todos, such as "Meditate" or "Water the plants".
+type Habit struct {
+	UID              string    `json:"uid"`
+	Label            string    `json:"label"`
+	EstimatedMinutes int       `json:"estimated_minutes"`
+	Type             HabitType `json:"type"`
+	// Weekdays lists which days of the week the habit applies to. An empty
+	// slice means every day.
+	Weekdays []time.Weekday `json:"weekdays,omitempty"`
+}
+
+// NewHabit creates a new Habit with the given schedule.
+func NewHabit(label string, estimatedMinutes int, habitType HabitType, weekdays []time.Weekday) Habit {
+	return Habit{
+		UID:              NewUID(),
+		Label:            label,
+		EstimatedMinutes: estimatedMinutes,
+		Type:             habitType,
+		Weekdays:         weekdays,
+	}
+}
+
+// ScheduledOn reports whether the habit applies on the given date's weekday.
+func (h Habit) ScheduledOn(date time.Time) bool {
+	if len(h.Weekdays) == 0 {
+		return true
+	}
+	for _, wd := range h.Weekdays {
+		if wd == date.Weekday() {
+			return true
+		}
+	}
+	return false
+}
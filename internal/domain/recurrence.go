@@ -0,0 +1,405 @@
+package domain
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Frequency is the base repetition unit of a Recurrence, mirroring the
+// RFC 5545 FREQ values chronoflow supports.
+type Frequency int
+
+const (
+	FreqDaily Frequency = iota
+	FreqWeekly
+	FreqMonthly
+	FreqYearly
+)
+
+func (f Frequency) String() string {
+	switch f {
+	case FreqWeekly:
+		return "WEEKLY"
+	case FreqMonthly:
+		return "MONTHLY"
+	case FreqYearly:
+		return "YEARLY"
+	default:
+		return "DAILY"
+	}
+}
+
+// weekdayCodes maps RFC 5545's two-letter weekday codes to time.Weekday.
+var weekdayCodes = map[string]time.Weekday{
+	"SU": time.Sunday, "MO": time.Monday, "TU": time.Tuesday, "WE": time.Wednesday,
+	"TH": time.Thursday, "FR": time.Friday, "SA": time.Saturday,
+}
+
+var weekdayNames = [...]string{"SU", "MO", "TU", "WE", "TH", "FR", "SA"}
+
+// Recurrence describes a repeating schedule for a Todo, modeled after a
+// small subset of RFC 5545 RRULE: FREQ, INTERVAL, BYDAY, COUNT and UNTIL,
+// plus EXDATE exception dates that are otherwise-matching occurrences to
+// skip (e.g. a single holiday pulled out of a daily series).
+//
+// Occurrences are expanded lazily via Occurrences/Matches rather than
+// materialized on disk — see TodoService.ExpandOccurrences, which
+// syncCalendarTodos (cmd/chronoflow/main.go) uses to paint every
+// occurrence in the visible month/week onto the calendar heatmap.
+// TodoService.ToggleOccurrence/UpdateOccurrence/SplitSeries record
+// per-occurrence overrides (via ExceptionDates or a split-off series)
+// rather than mutating the whole rule; ui.StateEditScope is the "this
+// occurrence / this and future / all events" prompt routed in front of
+// both editing and deleting a recurring todo's occurrence.
+type Recurrence struct {
+	Freq     Frequency      `json:"freq"`
+	Interval int            `json:"interval"` // 0 is treated as 1
+	ByDay    []time.Weekday `json:"by_day,omitempty"`
+	// ByMonthDay pins a FreqMonthly series to specific days of the month
+	// (RFC 5545 BYMONTHDAY) instead of the default "same day of month as
+	// DTSTART". A month without that day (e.g. BYMONTHDAY=31 in February)
+	// simply has no occurrence that month. Only FreqMonthly honors this;
+	// FreqYearly still anchors to dtstart's day/month, same as before.
+	ByMonthDay     []int       `json:"by_month_day,omitempty"`
+	Count          int         `json:"count,omitempty"` // 0 = unbounded
+	Until          time.Time   `json:"until,omitempty"` // zero = unbounded
+	ExceptionDates []time.Time `json:"exception_dates,omitempty"`
+}
+
+// IsException reports whether date was explicitly excluded from the
+// series via EXDATE.
+func (r Recurrence) IsException(date time.Time) bool {
+	date = normalizeDay(date)
+	for _, ex := range r.ExceptionDates {
+		if normalizeDay(ex).Equal(date) {
+			return true
+		}
+	}
+	return false
+}
+
+// ParseRRule parses an RFC 5545-style RRULE string, e.g.
+// "FREQ=WEEKLY;INTERVAL=1;BYDAY=MO,WE,FR" or "FREQ=MONTHLY;COUNT=6".
+func ParseRRule(s string) (Recurrence, error) {
+	r := Recurrence{Interval: 1}
+	sawFreq := false
+
+	for _, part := range strings.Split(s, ";") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		kv := strings.SplitN(part, "=", 2)
+		if len(kv) != 2 {
+			return Recurrence{}, fmt.Errorf("domain: malformed RRULE part %q", part)
+		}
+		key, val := strings.ToUpper(kv[0]), kv[1]
+
+		switch key {
+		case "FREQ":
+			switch strings.ToUpper(val) {
+			case "DAILY":
+				r.Freq = FreqDaily
+			case "WEEKLY":
+				r.Freq = FreqWeekly
+			case "MONTHLY":
+				r.Freq = FreqMonthly
+			case "YEARLY":
+				r.Freq = FreqYearly
+			default:
+				return Recurrence{}, fmt.Errorf("domain: unsupported FREQ %q", val)
+			}
+			sawFreq = true
+		case "INTERVAL":
+			n, err := strconv.Atoi(val)
+			if err != nil || n < 1 {
+				return Recurrence{}, fmt.Errorf("domain: invalid INTERVAL %q", val)
+			}
+			r.Interval = n
+		case "COUNT":
+			n, err := strconv.Atoi(val)
+			if err != nil || n < 1 {
+				return Recurrence{}, fmt.Errorf("domain: invalid COUNT %q", val)
+			}
+			r.Count = n
+		case "UNTIL":
+			t, err := time.ParseInLocation("20060102", val, time.Local)
+			if err != nil {
+				return Recurrence{}, fmt.Errorf("domain: invalid UNTIL %q", val)
+			}
+			r.Until = t
+		case "BYDAY":
+			for _, code := range strings.Split(val, ",") {
+				wd, ok := weekdayCodes[strings.ToUpper(code)]
+				if !ok {
+					return Recurrence{}, fmt.Errorf("domain: invalid BYDAY %q", code)
+				}
+				r.ByDay = append(r.ByDay, wd)
+			}
+		case "BYMONTHDAY":
+			seen := make(map[int]bool)
+			for _, code := range strings.Split(val, ",") {
+				n, err := strconv.Atoi(code)
+				if err != nil || n < 1 || n > 31 {
+					return Recurrence{}, fmt.Errorf("domain: invalid BYMONTHDAY %q", code)
+				}
+				if seen[n] {
+					continue // dedupe so COUNT-limited rules don't double-count a repeated day
+				}
+				seen[n] = true
+				r.ByMonthDay = append(r.ByMonthDay, n)
+			}
+		case "EXDATE":
+			for _, d := range strings.Split(val, ",") {
+				t, err := time.ParseInLocation("20060102", d, time.Local)
+				if err != nil {
+					return Recurrence{}, fmt.Errorf("domain: invalid EXDATE %q", d)
+				}
+				r.ExceptionDates = append(r.ExceptionDates, t)
+			}
+		}
+	}
+
+	if !sawFreq {
+		return Recurrence{}, fmt.Errorf("domain: RRULE missing FREQ")
+	}
+	return r, nil
+}
+
+// String serializes the Recurrence back to an RFC 5545-style RRULE string.
+func (r Recurrence) String() string {
+	parts := []string{"FREQ=" + r.Freq.String()}
+
+	interval := r.Interval
+	if interval < 1 {
+		interval = 1
+	}
+	if interval > 1 {
+		parts = append(parts, fmt.Sprintf("INTERVAL=%d", interval))
+	}
+
+	if len(r.ByDay) > 0 {
+		days := make([]time.Weekday, len(r.ByDay))
+		copy(days, r.ByDay)
+		sort.Slice(days, func(i, j int) bool { return days[i] < days[j] })
+		codes := make([]string, len(days))
+		for i, wd := range days {
+			codes[i] = weekdayNames[wd]
+		}
+		parts = append(parts, "BYDAY="+strings.Join(codes, ","))
+	}
+
+	if len(r.ByMonthDay) > 0 {
+		days := make([]int, len(r.ByMonthDay))
+		copy(days, r.ByMonthDay)
+		sort.Ints(days)
+		codes := make([]string, len(days))
+		for i, d := range days {
+			codes[i] = strconv.Itoa(d)
+		}
+		parts = append(parts, "BYMONTHDAY="+strings.Join(codes, ","))
+	}
+
+	if r.Count > 0 {
+		parts = append(parts, fmt.Sprintf("COUNT=%d", r.Count))
+	}
+	if !r.Until.IsZero() {
+		parts = append(parts, "UNTIL="+r.Until.Format("20060102"))
+	}
+
+	if len(r.ExceptionDates) > 0 {
+		dates := make([]time.Time, len(r.ExceptionDates))
+		copy(dates, r.ExceptionDates)
+		sort.Slice(dates, func(i, j int) bool { return dates[i].Before(dates[j]) })
+		codes := make([]string, len(dates))
+		for i, d := range dates {
+			codes[i] = d.Format("20060102")
+		}
+		parts = append(parts, "EXDATE="+strings.Join(codes, ","))
+	}
+
+	return strings.Join(parts, ";")
+}
+
+func normalizeDay(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+}
+
+func startOfWeek(t time.Time) time.Time {
+	t = normalizeDay(t)
+	return t.AddDate(0, 0, -int(t.Weekday()))
+}
+
+// Matches reports whether date is an occurrence of this recurrence rule
+// when the series starts on dtstart.
+func (r Recurrence) Matches(dtstart, date time.Time) bool {
+	dtstart = normalizeDay(dtstart)
+	date = normalizeDay(date)
+	if date.Before(dtstart) {
+		return false
+	}
+	if !r.Until.IsZero() && date.After(normalizeDay(r.Until)) {
+		return false
+	}
+	if r.IsException(date) {
+		return false
+	}
+
+	interval := r.Interval
+	if interval < 1 {
+		interval = 1
+	}
+
+	switch r.Freq {
+	case FreqWeekly:
+		byDay := r.ByDay
+		if len(byDay) == 0 {
+			byDay = []time.Weekday{dtstart.Weekday()}
+		}
+		weeksBetween := int(startOfWeek(date).Sub(startOfWeek(dtstart)).Hours() / 24 / 7)
+		if weeksBetween%interval != 0 {
+			return false
+		}
+		matchesDay := false
+		for _, wd := range byDay {
+			if wd == date.Weekday() {
+				matchesDay = true
+				break
+			}
+		}
+		if !matchesDay {
+			return false
+		}
+		if r.Count > 0 {
+			return r.countOccurrencesUpTo(dtstart, date) <= r.Count
+		}
+		return true
+
+	case FreqMonthly:
+		if len(r.ByMonthDay) > 0 {
+			matchesDay := false
+			for _, d := range r.ByMonthDay {
+				if d == date.Day() {
+					matchesDay = true
+					break
+				}
+			}
+			if !matchesDay {
+				return false
+			}
+		} else if date.Day() != dtstart.Day() {
+			return false
+		}
+		monthsBetween := (date.Year()-dtstart.Year())*12 + int(date.Month()-dtstart.Month())
+		if monthsBetween < 0 || monthsBetween%interval != 0 {
+			return false
+		}
+		if r.Count > 0 {
+			if len(r.ByMonthDay) > 1 {
+				return r.countMonthDayOccurrencesUpTo(dtstart, date) <= r.Count
+			}
+			return monthsBetween/interval < r.Count
+		}
+		return true
+
+	case FreqYearly:
+		if date.Day() != dtstart.Day() || date.Month() != dtstart.Month() {
+			return false
+		}
+		yearsBetween := date.Year() - dtstart.Year()
+		if yearsBetween < 0 || yearsBetween%interval != 0 {
+			return false
+		}
+		if r.Count > 0 {
+			return yearsBetween/interval < r.Count
+		}
+		return true
+
+	default: // FreqDaily
+		days := int(date.Sub(dtstart).Hours() / 24)
+		if days%interval != 0 {
+			return false
+		}
+		if r.Count > 0 {
+			return days/interval < r.Count
+		}
+		return true
+	}
+}
+
+// countOccurrencesUpTo counts how many weekly occurrences fall in
+// [dtstart, date], used to enforce COUNT for BYDAY-based weekly rules
+// where more than one occurrence can land in the same week.
+func (r Recurrence) countOccurrencesUpTo(dtstart, date time.Time) int {
+	interval := r.Interval
+	if interval < 1 {
+		interval = 1
+	}
+	byDay := r.ByDay
+	if len(byDay) == 0 {
+		byDay = []time.Weekday{dtstart.Weekday()}
+	}
+	sortedDays := make([]time.Weekday, len(byDay))
+	copy(sortedDays, byDay)
+	sort.Slice(sortedDays, func(i, j int) bool { return sortedDays[i] < sortedDays[j] })
+
+	count := 0
+	week := startOfWeek(dtstart)
+	for !week.After(date) {
+		for _, wd := range sortedDays {
+			occ := week.AddDate(0, 0, int(wd))
+			if occ.Before(dtstart) || occ.After(date) {
+				continue
+			}
+			count++
+		}
+		week = week.AddDate(0, 0, 7*interval)
+	}
+	return count
+}
+
+// countMonthDayOccurrencesUpTo counts how many monthly occurrences fall in
+// [dtstart, date], used to enforce COUNT for BYMONTHDAY-based monthly rules
+// where more than one occurrence can land in the same month.
+func (r Recurrence) countMonthDayOccurrencesUpTo(dtstart, date time.Time) int {
+	interval := r.Interval
+	if interval < 1 {
+		interval = 1
+	}
+	sortedDays := make([]int, len(r.ByMonthDay))
+	copy(sortedDays, r.ByMonthDay)
+	sort.Ints(sortedDays)
+
+	count := 0
+	month := time.Date(dtstart.Year(), dtstart.Month(), 1, 0, 0, 0, 0, dtstart.Location())
+	last := time.Date(date.Year(), date.Month(), 1, 0, 0, 0, 0, date.Location())
+	for !month.After(last) {
+		for _, d := range sortedDays {
+			occ := time.Date(month.Year(), month.Month(), d, 0, 0, 0, 0, month.Location())
+			if occ.Month() != month.Month() {
+				continue // d doesn't exist in this month (e.g. 31 in April)
+			}
+			if occ.Before(dtstart) || occ.After(date) {
+				continue
+			}
+			count++
+		}
+		month = month.AddDate(0, interval, 0)
+	}
+	return count
+}
+
+// Occurrences returns every date in [start, end] (inclusive) that this
+// recurrence generates for a series beginning on dtstart.
+func (r Recurrence) Occurrences(dtstart, start, end time.Time) []time.Time {
+	var dates []time.Time
+	for d := normalizeDay(start); !d.After(end); d = d.AddDate(0, 0, 1) {
+		if r.Matches(dtstart, d) {
+			dates = append(dates, d)
+		}
+	}
+	return dates
+}
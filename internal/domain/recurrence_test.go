@@ -0,0 +1,153 @@
+package domain
+
+import (
+	"testing"
+	"time"
+)
+
+func mustDate(s string) time.Time {
+	t, err := time.ParseInLocation("2006-01-02", s, time.Local)
+	if err != nil {
+		panic(err)
+	}
+	return t
+}
+
+func TestParseRRuleWeeklyOnMWF(t *testing.T) {
+	r, err := ParseRRule("FREQ=WEEKLY;BYDAY=MO,WE,FR")
+	if err != nil {
+		t.Fatalf("ParseRRule: %v", err)
+	}
+
+	dtstart := mustDate("2026-03-02") // Monday
+	want := map[string]bool{
+		"2026-03-02": true,  // Mon
+		"2026-03-03": false, // Tue
+		"2026-03-04": true,  // Wed
+		"2026-03-06": true,  // Fri
+		"2026-03-09": true,  // next Mon
+	}
+	for dateStr, expect := range want {
+		got := r.Matches(dtstart, mustDate(dateStr))
+		if got != expect {
+			t.Errorf("Matches(%s) = %v, want %v", dateStr, got, expect)
+		}
+	}
+
+	if got := r.String(); got != "FREQ=WEEKLY;BYDAY=MO,WE,FR" {
+		t.Errorf("String() = %q", got)
+	}
+}
+
+func TestParseRRuleMonthlyOn15th(t *testing.T) {
+	r, err := ParseRRule("FREQ=MONTHLY")
+	if err != nil {
+		t.Fatalf("ParseRRule: %v", err)
+	}
+	dtstart := mustDate("2026-01-15")
+
+	if !r.Matches(dtstart, mustDate("2026-02-15")) {
+		t.Error("expected Feb 15 to match monthly-on-15th")
+	}
+	if !r.Matches(dtstart, mustDate("2026-07-15")) {
+		t.Error("expected Jul 15 to match monthly-on-15th")
+	}
+	if r.Matches(dtstart, mustDate("2026-02-16")) {
+		t.Error("expected Feb 16 not to match monthly-on-15th")
+	}
+}
+
+func TestParseRRuleMonthlyByMonthDay(t *testing.T) {
+	r, err := ParseRRule("FREQ=MONTHLY;BYMONTHDAY=1,15")
+	if err != nil {
+		t.Fatalf("ParseRRule: %v", err)
+	}
+	dtstart := mustDate("2026-01-01")
+
+	if !r.Matches(dtstart, mustDate("2026-02-01")) {
+		t.Error("expected Feb 1 to match BYMONTHDAY=1,15")
+	}
+	if !r.Matches(dtstart, mustDate("2026-02-15")) {
+		t.Error("expected Feb 15 to match BYMONTHDAY=1,15")
+	}
+	if r.Matches(dtstart, mustDate("2026-02-16")) {
+		t.Error("expected Feb 16 not to match BYMONTHDAY=1,15")
+	}
+	if r.String() != "FREQ=MONTHLY;BYMONTHDAY=1,15" {
+		t.Errorf("String() = %q", r.String())
+	}
+}
+
+func TestParseRRuleEveryNDays(t *testing.T) {
+	r, err := ParseRRule("FREQ=DAILY;INTERVAL=3")
+	if err != nil {
+		t.Fatalf("ParseRRule: %v", err)
+	}
+	dtstart := mustDate("2026-03-01")
+
+	if !r.Matches(dtstart, mustDate("2026-03-04")) {
+		t.Error("expected day+3 to match every-3-days rule")
+	}
+	if r.Matches(dtstart, mustDate("2026-03-03")) {
+		t.Error("expected day+2 not to match every-3-days rule")
+	}
+}
+
+func TestParseRRuleCountLimited(t *testing.T) {
+	r, err := ParseRRule("FREQ=DAILY;COUNT=3")
+	if err != nil {
+		t.Fatalf("ParseRRule: %v", err)
+	}
+	dtstart := mustDate("2026-03-01")
+
+	occurrences := r.Occurrences(dtstart, dtstart, mustDate("2026-03-10"))
+	if len(occurrences) != 3 {
+		t.Fatalf("expected 3 occurrences, got %d: %v", len(occurrences), occurrences)
+	}
+	if r.Matches(dtstart, mustDate("2026-03-04")) {
+		t.Error("expected 4th day to be excluded by COUNT=3")
+	}
+}
+
+func TestParseRRuleRejectsMissingFreq(t *testing.T) {
+	if _, err := ParseRRule("INTERVAL=2"); err == nil {
+		t.Error("expected error for RRULE missing FREQ")
+	}
+}
+
+func TestParseRRuleYearly(t *testing.T) {
+	r, err := ParseRRule("FREQ=YEARLY")
+	if err != nil {
+		t.Fatalf("ParseRRule: %v", err)
+	}
+	dtstart := mustDate("2026-03-02")
+
+	if !r.Matches(dtstart, mustDate("2027-03-02")) {
+		t.Error("expected same day next year to match yearly rule")
+	}
+	if r.Matches(dtstart, mustDate("2027-03-03")) {
+		t.Error("expected a different day next year not to match yearly rule")
+	}
+	if r.Matches(dtstart, mustDate("2026-03-02")) == false {
+		t.Error("expected dtstart itself to match yearly rule")
+	}
+}
+
+func TestParseRRuleExdateSkipsOccurrence(t *testing.T) {
+	r, err := ParseRRule("FREQ=DAILY;EXDATE=20260303")
+	if err != nil {
+		t.Fatalf("ParseRRule: %v", err)
+	}
+	dtstart := mustDate("2026-03-01")
+
+	if r.Matches(dtstart, mustDate("2026-03-03")) {
+		t.Error("expected excepted date not to match")
+	}
+	if !r.Matches(dtstart, mustDate("2026-03-02")) {
+		t.Error("expected neighboring date to still match")
+	}
+
+	if got := r.String(); got != "FREQ=DAILY;EXDATE=20260303" {
+		t.Errorf("String() = %q", got)
+	}
+}
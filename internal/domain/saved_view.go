@@ -0,0 +1,22 @@
+package domain
+
+// SavedView is a named, persisted search query — a "smart list" the user
+// can jump back to without retyping it. Query uses the same syntax
+// search.ParseQuery understands (bare terms, prefix*, "phrases", and
+// title:/date:/is:/p: filters), so re-running it reproduces the view
+// exactly; SavedView itself carries no separate filter representation.
+type SavedView struct {
+	UID    string `json:"uid"`
+	Name   string `json:"name"`
+	Query  string `json:"query"`
+	Pinned bool   `json:"pinned"`
+}
+
+// NewSavedView creates a SavedView with a fresh UID.
+func NewSavedView(name, query string) SavedView {
+	return SavedView{
+		UID:   NewUID(),
+		Name:  name,
+		Query: query,
+	}
+}
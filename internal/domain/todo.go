@@ -4,15 +4,86 @@ import "time"
 
 // Todo represents a todo item (pure data, no UI concerns)
 type Todo struct {
+	UID          string      `json:"uid"`
+	Title        string      `json:"title"`
+	Desc         string      `json:"desc"`
+	Complete     bool        `json:"completed"`
+	Priority     Priority    `json:"priority"`
+	LastModified time.Time   `json:"last_modified"`
+	Recurrence   *Recurrence `json:"recurrence,omitempty"`
+	// CompletedOccurrences holds the "2006-01-02" date keys of individual
+	// occurrences that have been checked off, for todos with a Recurrence.
+	// The Complete field above is not meaningful on a recurring todo.
+	CompletedOccurrences []string `json:"completed_occurrences,omitempty"`
+	// Overrides holds per-occurrence edits (keyed by "2006-01-02" date), for
+	// a recurring todo edited with the "this event only" scope rather than
+	// "all events". Dates absent here display the master's Title/Desc/
+	// Priority unchanged.
+	Overrides map[string]Override `json:"overrides,omitempty"`
+	// ParentUID points at the UID of the todo this one is a subtask of.
+	// Empty means a top-level todo; existing data needs no migration since
+	// the zero value already means "top-level".
+	ParentUID string `json:"parent_uid,omitempty"`
+	// DueAt holds an optional time-of-day the todo is due. The zero value
+	// means no specific time was set, as opposed to the todo's date (which
+	// is tracked separately by the repository's date key).
+	DueAt time.Time `json:"due_at,omitempty"`
+	// EndDate holds the last day a multi-day todo spans, inclusive. The zero
+	// value means the todo is single-day, ending on the repository's date
+	// key the same as it starts.
+	EndDate time.Time `json:"end_date,omitempty"`
+	// AlarmOffset is how long before DueAt a reminder should fire. It is
+	// only meaningful when DueAt is set; the zero value means no reminder.
+	AlarmOffset time.Duration `json:"alarm_offset,omitempty"`
+}
+
+// Override holds the fields a single occurrence of a recurring todo can
+// diverge from its master on, applied by TodoService when expanding that
+// occurrence (see TodoService.UpdateOccurrence).
+type Override struct {
 	Title    string   `json:"title"`
 	Desc     string   `json:"desc"`
-	Complete bool     `json:"completed"`
 	Priority Priority `json:"priority"`
 }
 
+// WithOverride returns a copy of t with fields from the override at dateKey
+// (if any) applied. Intended for use on a single materialized occurrence,
+// not the stored master.
+func (t Todo) WithOverride(dateKey string) Todo {
+	ov, ok := t.Overrides[dateKey]
+	if !ok {
+		return t
+	}
+	t.Title = ov.Title
+	t.Desc = ov.Desc
+	t.Priority = ov.Priority
+	return t
+}
+
+// HasDueTime reports whether a specific due time was set on the todo.
+func (t Todo) HasDueTime() bool {
+	return !t.DueAt.IsZero()
+}
+
+// ReminderAt returns when a reminder for this todo should fire (DueAt minus
+// AlarmOffset). The second return value is false when there's nothing to
+// remind about: no due time, no alarm offset, or the todo is already done.
+func (t Todo) ReminderAt() (time.Time, bool) {
+	if t.Complete || !t.HasDueTime() || t.AlarmOffset <= 0 {
+		return time.Time{}, false
+	}
+	return t.DueAt.Add(-t.AlarmOffset), true
+}
+
+// HasEndDate reports whether the todo spans more than one day.
+func (t Todo) HasEndDate() bool {
+	return !t.EndDate.IsZero()
+}
+
 // NewTodo creates a new Todo with the given title
 func NewTodo(title string) Todo {
 	return Todo{
+		UID:      NewUID(),
 		Title:    title,
 		Priority: PriorityNone,
 	}
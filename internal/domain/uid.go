@@ -0,0 +1,22 @@
+package domain
+
+import (
+	"crypto/rand"
+	"fmt"
+)
+
+// NewUID generates a random RFC 4122 version 4 UID, used to give todos a
+// stable identity that survives edits across external systems (CalDAV
+// objects, iCalendar exports) that key off UID rather than position.
+func NewUID() string {
+	var b [16]byte
+	if _, err := rand.Read(b[:]); err != nil {
+		// crypto/rand.Read only fails if the OS RNG is unavailable; fall
+		// back to an all-zero UID rather than panicking.
+		return "00000000-0000-4000-8000-000000000000"
+	}
+	b[6] = (b[6] & 0x0f) | 0x40 // version 4
+	b[8] = (b[8] & 0x3f) | 0x80 // variant 10
+
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:16])
+}
@@ -0,0 +1,108 @@
+// Package habits models recurring daily habits, kept separate from the
+// one-off todos in internal/service so the two concerns (a checklist for
+// today vs. a routine repeated on a weekday schedule) don't get tangled.
+package habits
+
+import (
+	"time"
+
+	"ctchen222/chronoflow/internal/domain"
+	"ctchen222/chronoflow/internal/repository"
+	"ctchen222/chronoflow/internal/service"
+)
+
+// maxStreakLookback bounds how far Streak walks back into the past, since
+// habits carry no creation date to stop at.
+const maxStreakLookback = 365
+
+// HabitService provides business logic operations for habits, mirroring
+// service.TodoService's shape.
+type HabitService struct {
+	habits     repository.HabitRepository
+	completion repository.HabitCompletionRepository
+	timeProv   service.TimeProvider
+}
+
+// NewHabitService creates a new HabitService.
+func NewHabitService(habits repository.HabitRepository, completion repository.HabitCompletionRepository, timeProv service.TimeProvider) *HabitService {
+	return &HabitService{
+		habits:     habits,
+		completion: completion,
+		timeProv:   timeProv,
+	}
+}
+
+// HabitsForDate returns the habits scheduled for date, i.e. those whose
+// weekday schedule includes date's weekday.
+func (s *HabitService) HabitsForDate(date time.Time) []domain.Habit {
+	var scheduled []domain.Habit
+	for _, h := range s.habits.GetAll() {
+		if h.ScheduledOn(date) {
+			scheduled = append(scheduled, h)
+		}
+	}
+	return scheduled
+}
+
+// IsDone reports whether habitUID was completed on date.
+func (s *HabitService) IsDone(habitUID string, date time.Time) bool {
+	return s.completion.IsDone(habitUID, date)
+}
+
+// ToggleDone flips whether habitUID is marked done on date.
+func (s *HabitService) ToggleDone(habitUID string, date time.Time) error {
+	done := s.completion.IsDone(habitUID, date)
+	return s.completion.SetDone(habitUID, date, !done)
+}
+
+// Add creates a new habit with the given schedule.
+func (s *HabitService) Add(label string, estimatedMinutes int, habitType domain.HabitType, weekdays []time.Weekday) error {
+	if label == "" {
+		return nil
+	}
+	return s.habits.Add(domain.NewHabit(label, estimatedMinutes, habitType, weekdays))
+}
+
+// Delete removes the habit with the given UID.
+func (s *HabitService) Delete(habitUID string) error {
+	return s.habits.Delete(habitUID)
+}
+
+// Streak returns the current consecutive-day completion length for
+// habitUID, counting back from today over the days it's scheduled on and
+// stopping at the first scheduled day it was missed.
+func (s *HabitService) Streak(habitUID string) int {
+	var habit domain.Habit
+	found := false
+	for _, h := range s.habits.GetAll() {
+		if h.UID == habitUID {
+			habit = h
+			found = true
+			break
+		}
+	}
+	if !found {
+		return 0
+	}
+
+	streak := 0
+	day := s.timeProv.Today()
+	for i := 0; i < maxStreakLookback; i++ {
+		if habit.ScheduledOn(day) {
+			if !s.completion.IsDone(habitUID, day) {
+				break
+			}
+			streak++
+		}
+		day = day.AddDate(0, 0, -1)
+	}
+	return streak
+}
+
+// Persist saves both habit definitions and completion history.
+func (s *HabitService) Persist() error {
+	if err := s.habits.Persist(); err != nil {
+		return err
+	}
+	return s.completion.Persist()
+}
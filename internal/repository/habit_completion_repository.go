@@ -0,0 +1,106 @@
+package repository
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// HabitCompletionRepository defines the interface for tracking which
+// habits were completed on which dates. It is kept separate from
+// HabitRepository so a habit's schedule and its completion history can
+// evolve (and be stored) independently.
+type HabitCompletionRepository interface {
+	// IsDone reports whether habitUID was completed on date.
+	IsDone(habitUID string, date time.Time) bool
+
+	// SetDone marks habitUID as done (or not done) on date.
+	SetDone(habitUID string, date time.Time, done bool) error
+
+	// Load loads completion history from persistent storage.
+	Load() error
+
+	// Persist saves completion history to persistent storage.
+	Persist() error
+}
+
+// JSONHabitCompletionRepository implements HabitCompletionRepository using
+// JSON file storage, keyed by date ("2006-01-02") to the UIDs of habits
+// completed that day.
+type JSONHabitCompletionRepository struct {
+	done     map[string][]string
+	filePath string
+}
+
+// NewJSONHabitCompletionRepository creates a new JSON-based completion repository.
+func NewJSONHabitCompletionRepository(filePath string) *JSONHabitCompletionRepository {
+	return &JSONHabitCompletionRepository{
+		done:     make(map[string][]string),
+		filePath: filePath,
+	}
+}
+
+func completionDateKey(date time.Time) string {
+	return date.Format("2006-01-02")
+}
+
+func (r *JSONHabitCompletionRepository) IsDone(habitUID string, date time.Time) bool {
+	for _, uid := range r.done[completionDateKey(date)] {
+		if uid == habitUID {
+			return true
+		}
+	}
+	return false
+}
+
+func (r *JSONHabitCompletionRepository) SetDone(habitUID string, date time.Time, done bool) error {
+	key := completionDateKey(date)
+	uids := r.done[key]
+
+	idx := -1
+	for i, uid := range uids {
+		if uid == habitUID {
+			idx = i
+			break
+		}
+	}
+
+	if done {
+		if idx == -1 {
+			r.done[key] = append(uids, habitUID)
+		}
+		return nil
+	}
+	if idx != -1 {
+		r.done[key] = append(uids[:idx], uids[idx+1:]...)
+	}
+	return nil
+}
+
+func (r *JSONHabitCompletionRepository) Load() error {
+	data, err := os.ReadFile(r.filePath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil // No file, start fresh
+		}
+		return err
+	}
+	if len(data) == 0 {
+		return nil
+	}
+	return json.Unmarshal(data, &r.done)
+}
+
+func (r *JSONHabitCompletionRepository) Persist() error {
+	dir := filepath.Dir(r.filePath)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return err
+	}
+
+	data, err := json.MarshalIndent(r.done, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(r.filePath, data, 0644)
+}
@@ -0,0 +1,86 @@
+package repository
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+
+	"ctchen222/chronoflow/internal/domain"
+)
+
+// HabitRepository defines the interface for habit definition data access.
+type HabitRepository interface {
+	// GetAll returns every defined habit.
+	GetAll() []domain.Habit
+
+	// Add adds a new habit.
+	Add(habit domain.Habit) error
+
+	// Delete removes the habit with the given UID.
+	Delete(uid string) error
+
+	// Load loads habits from persistent storage.
+	Load() error
+
+	// Persist saves habits to persistent storage.
+	Persist() error
+}
+
+// JSONHabitRepository implements HabitRepository using JSON file storage.
+type JSONHabitRepository struct {
+	habits   []domain.Habit
+	filePath string
+}
+
+// NewJSONHabitRepository creates a new JSON-based habit repository.
+func NewJSONHabitRepository(filePath string) *JSONHabitRepository {
+	return &JSONHabitRepository{filePath: filePath}
+}
+
+func (r *JSONHabitRepository) GetAll() []domain.Habit {
+	result := make([]domain.Habit, len(r.habits))
+	copy(result, r.habits)
+	return result
+}
+
+func (r *JSONHabitRepository) Add(habit domain.Habit) error {
+	r.habits = append(r.habits, habit)
+	return nil
+}
+
+func (r *JSONHabitRepository) Delete(uid string) error {
+	for i, h := range r.habits {
+		if h.UID == uid {
+			r.habits = append(r.habits[:i], r.habits[i+1:]...)
+			return nil
+		}
+	}
+	return nil
+}
+
+func (r *JSONHabitRepository) Load() error {
+	data, err := os.ReadFile(r.filePath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil // No file, start fresh
+		}
+		return err
+	}
+	if len(data) == 0 {
+		return nil
+	}
+	return json.Unmarshal(data, &r.habits)
+}
+
+func (r *JSONHabitRepository) Persist() error {
+	dir := filepath.Dir(r.filePath)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return err
+	}
+
+	data, err := json.MarshalIndent(r.habits, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(r.filePath, data, 0644)
+}
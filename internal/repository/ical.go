@@ -0,0 +1,217 @@
+package repository
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+
+	"github.com/emersion/go-ical"
+
+	"ctchen222/chronoflow/internal/domain"
+)
+
+// dateLayout matches the date-key format produced by dateKey.
+const dateLayout = "2006-01-02"
+
+// Import reads a VCALENDAR document from reader and merges one todo per
+// VTODO it contains into the date bucket derived from its DUE property: a
+// VTODO whose UID matches an existing todo (in any date bucket) replaces
+// it, others are added as new. VTODOs with an unparsable DUE are skipped.
+func (r *JSONTodoRepository) Import(reader io.Reader) error {
+	cal, err := ical.NewDecoder(reader).Decode()
+	if err != nil {
+		return fmt.Errorf("repository: decode iCalendar: %w", err)
+	}
+
+	for _, comp := range cal.Children {
+		if comp.Name != ical.CompToDo {
+			continue
+		}
+		key, todo, err := vtodoToTodo(comp)
+		if err != nil {
+			continue
+		}
+		r.upsertByUID(key, todo)
+	}
+	return nil
+}
+
+// upsertByUID files todo under key, removing any existing todo with the
+// same UID from whichever date bucket it's currently in first, so a
+// re-import updates it in place instead of appending a duplicate. Fields
+// iCalendar doesn't carry (subtask links, recurrence, per-occurrence
+// overrides, due time, alarm) are carried over from the existing todo
+// rather than wiped, since vtodoToTodo never sets them.
+func (r *JSONTodoRepository) upsertByUID(key string, todo domain.Todo) {
+	if todo.UID != "" {
+		for existingKey, todos := range r.todos {
+			for i, t := range todos {
+				if t.UID == todo.UID {
+					todo.ParentUID = t.ParentUID
+					todo.Recurrence = t.Recurrence
+					todo.CompletedOccurrences = t.CompletedOccurrences
+					todo.Overrides = t.Overrides
+					todo.DueAt = t.DueAt
+					todo.EndDate = t.EndDate
+					todo.AlarmOffset = t.AlarmOffset
+					r.todos[existingKey] = append(todos[:i], todos[i+1:]...)
+					break
+				}
+			}
+		}
+	}
+	r.todos[key] = append(r.todos[key], todo)
+}
+
+// Export writes every todo in the repository as a VTODO inside a single
+// VCALENDAR document, for interchange with any RFC 5545-compatible tool.
+func (r *JSONTodoRepository) Export(w io.Writer) error {
+	return exportTodos(r.todos, w)
+}
+
+// ExportRange is like Export but writes only todos whose date key falls in
+// [from, to] (inclusive), for exporting just the range currently visible
+// on the calendar rather than the whole store.
+func (r *JSONTodoRepository) ExportRange(w io.Writer, from, to time.Time) error {
+	from, to = normalizeDay(from), normalizeDay(to)
+	filtered := make(map[string][]domain.Todo)
+	for key, todos := range r.todos {
+		date, err := time.ParseInLocation(dateLayout, key, time.Local)
+		if err != nil || date.Before(from) || date.After(to) {
+			continue
+		}
+		filtered[key] = todos
+	}
+	return exportTodos(filtered, w)
+}
+
+// exportTodos writes todos as one VTODO per entry inside a single
+// VCALENDAR document, shared by Export and ExportRange.
+func exportTodos(todos map[string][]domain.Todo, w io.Writer) error {
+	cal := ical.NewCalendar()
+	cal.Props.SetText(ical.PropVersion, "2.0")
+	cal.Props.SetText(ical.PropProductID, "-//chronoflow//chronoflow//EN")
+
+	for key, dateTodos := range todos {
+		for _, t := range dateTodos {
+			comp, err := todoToVTODO(key, t)
+			if err != nil {
+				return err
+			}
+			cal.Children = append(cal.Children, comp)
+		}
+	}
+	return ical.NewEncoder(w).Encode(cal)
+}
+
+func normalizeDay(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+}
+
+// todoToVTODO serializes a domain.Todo scheduled on dateKey into a VTODO
+// component, assigning a UID if it doesn't already have one.
+func todoToVTODO(dateKey string, t domain.Todo) (*ical.Component, error) {
+	due, err := time.ParseInLocation(dateLayout, dateKey, time.Local)
+	if err != nil {
+		return nil, fmt.Errorf("repository: parse date key %q: %w", dateKey, err)
+	}
+
+	uid := t.UID
+	if uid == "" {
+		uid = domain.NewUID()
+	}
+
+	modified := t.LastModified
+	if modified.IsZero() {
+		modified = due
+	}
+
+	comp := ical.NewComponent(ical.CompToDo)
+	comp.Props.SetText(ical.PropUID, uid)
+	comp.Props.SetText(ical.PropSummary, t.Title)
+	if t.Desc != "" {
+		comp.Props.SetText(ical.PropDescription, t.Desc)
+	}
+	comp.Props.SetDateTime(ical.PropDue, due)
+	comp.Props.SetDateTime(ical.PropDateTimeStamp, modified)
+	comp.Props.SetText(ical.PropStatus, icalStatus(t.Complete))
+	if prio := icalPriority(t.Priority); prio != 0 {
+		comp.Props.Set(&ical.Prop{Name: ical.PropPriority, Value: strconv.Itoa(prio)})
+	}
+	return comp, nil
+}
+
+// vtodoToTodo converts an imported VTODO component back into a domain.Todo
+// plus the date key it should be filed under (derived from DUE).
+func vtodoToTodo(comp *ical.Component) (dateKey string, t domain.Todo, err error) {
+	due, err := comp.Props.DateTime(ical.PropDue, time.Local)
+	if err != nil {
+		return "", domain.Todo{}, fmt.Errorf("repository: VTODO missing DUE: %w", err)
+	}
+
+	uid, _ := comp.Props.Text(ical.PropUID)
+	if uid == "" {
+		uid = domain.NewUID()
+	}
+	summary, _ := comp.Props.Text(ical.PropSummary)
+	desc, _ := comp.Props.Text(ical.PropDescription)
+	status, _ := comp.Props.Text(ical.PropStatus)
+
+	priority := domain.PriorityNone
+	if prop := comp.Props.Get(ical.PropPriority); prop != nil {
+		if p, err := prop.Int(); err == nil {
+			priority = priorityFromICal(p)
+		}
+	}
+
+	t = domain.Todo{
+		UID:          uid,
+		Title:        summary,
+		Desc:         desc,
+		Complete:     status == "COMPLETED",
+		Priority:     priority,
+		LastModified: time.Now(),
+	}
+	return due.Format(dateLayout), t, nil
+}
+
+// icalStatus maps completion to the iCalendar STATUS values chronoflow
+// round-trips on import/export.
+func icalStatus(complete bool) string {
+	if complete {
+		return "COMPLETED"
+	}
+	return "NEEDS-ACTION"
+}
+
+// icalPriority maps chronoflow's three-tier priority onto the iCalendar
+// PRIORITY scale (1=high, 5=mid, 9=low; 0=unset), matching internal/sync's
+// CalDAV mapping.
+func icalPriority(p domain.Priority) int {
+	switch p {
+	case domain.PriorityHigh:
+		return 1
+	case domain.PriorityMedium:
+		return 5
+	case domain.PriorityLow:
+		return 9
+	default:
+		return 0
+	}
+}
+
+// priorityFromICal is the inverse of icalPriority, rounding values that
+// don't land exactly on 1/5/9 to the nearest bucket.
+func priorityFromICal(p int) domain.Priority {
+	switch {
+	case p == 0:
+		return domain.PriorityNone
+	case p <= 3:
+		return domain.PriorityHigh
+	case p <= 6:
+		return domain.PriorityMedium
+	default:
+		return domain.PriorityLow
+	}
+}
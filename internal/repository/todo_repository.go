@@ -2,6 +2,7 @@ package repository
 
 import (
 	"encoding/json"
+	"io"
 	"os"
 	"path/filepath"
 	"time"
@@ -34,6 +35,20 @@ type TodoRepository interface {
 
 	// Persist saves todos to persistent storage
 	Persist() error
+
+	// Import reads a VCALENDAR document from r and merges each VTODO it
+	// contains (a todo with the same UID is updated rather than duplicated),
+	// for bringing in data from any RFC 5545-compatible tool.
+	Import(r io.Reader) error
+
+	// Export writes every todo as a VTODO inside a single VCALENDAR
+	// document, for interchange with any RFC 5545-compatible tool.
+	Export(w io.Writer) error
+
+	// ExportRange is like Export but writes only todos scheduled in
+	// [from, to] (inclusive), for exporting just a visible calendar range
+	// instead of the whole store.
+	ExportRange(w io.Writer, from, to time.Time) error
 }
 
 // JSONTodoRepository implements TodoRepository using JSON file storage
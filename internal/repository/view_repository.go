@@ -0,0 +1,99 @@
+package repository
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+
+	"ctchen222/chronoflow/internal/domain"
+)
+
+// ViewRepository defines the interface for saved-view data access.
+type ViewRepository interface {
+	// GetAll returns every saved view.
+	GetAll() []domain.SavedView
+
+	// Add adds a new saved view.
+	Add(view domain.SavedView) error
+
+	// Update replaces the saved view sharing view.UID.
+	Update(view domain.SavedView) error
+
+	// Delete removes the saved view with the given UID.
+	Delete(uid string) error
+
+	// Load loads saved views from persistent storage.
+	Load() error
+
+	// Persist saves views to persistent storage.
+	Persist() error
+}
+
+// JSONViewRepository implements ViewRepository using JSON file storage.
+type JSONViewRepository struct {
+	views    []domain.SavedView
+	filePath string
+}
+
+// NewJSONViewRepository creates a new JSON-based view repository.
+func NewJSONViewRepository(filePath string) *JSONViewRepository {
+	return &JSONViewRepository{filePath: filePath}
+}
+
+func (r *JSONViewRepository) GetAll() []domain.SavedView {
+	result := make([]domain.SavedView, len(r.views))
+	copy(result, r.views)
+	return result
+}
+
+func (r *JSONViewRepository) Add(view domain.SavedView) error {
+	r.views = append(r.views, view)
+	return nil
+}
+
+func (r *JSONViewRepository) Update(view domain.SavedView) error {
+	for i, v := range r.views {
+		if v.UID == view.UID {
+			r.views[i] = view
+			return nil
+		}
+	}
+	return nil
+}
+
+func (r *JSONViewRepository) Delete(uid string) error {
+	for i, v := range r.views {
+		if v.UID == uid {
+			r.views = append(r.views[:i], r.views[i+1:]...)
+			return nil
+		}
+	}
+	return nil
+}
+
+func (r *JSONViewRepository) Load() error {
+	data, err := os.ReadFile(r.filePath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil // No file, start fresh
+		}
+		return err
+	}
+	if len(data) == 0 {
+		return nil
+	}
+	return json.Unmarshal(data, &r.views)
+}
+
+func (r *JSONViewRepository) Persist() error {
+	dir := filepath.Dir(r.filePath)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return err
+	}
+
+	data, err := json.MarshalIndent(r.views, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(r.filePath, data, 0644)
+}
@@ -0,0 +1,72 @@
+package search
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"ctchen222/chronoflow/internal/domain"
+)
+
+// buildLargeIndex synthesizes n todos spread across roughly three years, so
+// Search has a realistic date range to tie-break over.
+func buildLargeIndex(n int) *Index {
+	idx := NewIndex()
+	titles := []string{
+		"Review quarterly report", "Renew passport", "Buy groceries",
+		"Project kickoff meeting", "Submit expense report", "Water the plants",
+		"Prepare board presentation", "Schedule dentist appointment",
+		"Plan team offsite", "Fix production incident",
+	}
+	base := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	for i := 0; i < n; i++ {
+		date := base.AddDate(0, 0, i%1000)
+		idx.Upsert(date.Format("2006-01-02"), domain.Todo{
+			UID:      fmt.Sprintf("uid-%d", i),
+			Title:    fmt.Sprintf("%s #%d", titles[i%len(titles)], i),
+			Priority: domain.Priority(i % 4),
+			Complete: i%3 == 0,
+		})
+	}
+	return idx
+}
+
+// BenchmarkSearch_10kTodos exercises the fuzzy-match path used by a
+// plain-term query across a 10k-todo index; this is the request's target
+// case for sub-10ms queries.
+func BenchmarkSearch_10kTodos(b *testing.B) {
+	idx := buildLargeIndex(10000)
+	q := ParseQuery("quarterly report")
+	today := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		idx.Search(q, today, false)
+	}
+}
+
+// BenchmarkSearch_10kTodosFiltered exercises the field-filter path (is:done
+// + p:high + date:) which additionally scans every candidate for filter
+// matches.
+func BenchmarkSearch_10kTodosFiltered(b *testing.B) {
+	idx := buildLargeIndex(10000)
+	q := ParseQuery("report is:done p:high date:2024")
+	today := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		idx.Search(q, today, false)
+	}
+}
+
+// BenchmarkUpsert_10kTodos measures incremental index maintenance cost for
+// a single todo update, which TodoService pays on every Add/Update/Delete.
+func BenchmarkUpsert_10kTodos(b *testing.B) {
+	idx := buildLargeIndex(10000)
+	todo := domain.Todo{UID: "uid-0", Title: "Review quarterly report #0 revised"}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		idx.Upsert("2024-01-01", todo)
+	}
+}
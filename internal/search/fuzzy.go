@@ -0,0 +1,161 @@
+package search
+
+import "unicode"
+
+// Fuzzy scoring constants, modeled after fzf's v2 algorithm: a flat reward
+// per matched rune, an affine gap penalty for runes skipped between
+// matches, and bonuses that reward matches falling at a "word start" or
+// immediately after a previous match.
+const (
+	fuzzyScoreMatch        = 16
+	fuzzyScoreGapStart     = -3
+	fuzzyScoreGapExtension = -1
+	fuzzyBonusBoundary     = 8
+	fuzzyBonusCamel        = 7
+	fuzzyBonusConsecutive  = 4
+	fuzzyBonusFirstChar    = 2
+)
+
+const fuzzyNegInf = -(1 << 30)
+
+// FuzzyMatch scores how well pattern fuzzy-matches text, fzf v2 style:
+// pattern runes must occur in text in the same order, though not
+// necessarily contiguously. Matches are preferred at word boundaries
+// (immediately after '/', '_', '-', '.', space, or a lowercase→uppercase
+// transition) and when consecutive, and penalized per rune skipped between
+// them. Returns ok=false if pattern isn't a subsequence of text.
+//
+// positions holds the rune index (not byte offset) of each matched pattern
+// rune within text, in order, for the caller to highlight.
+func FuzzyMatch(pattern, text string, caseSensitive bool) (score int, positions []int, ok bool) {
+	p := []rune(pattern)
+	t := []rune(text)
+	if len(p) == 0 {
+		return 0, nil, true
+	}
+	if len(p) > len(t) {
+		return 0, nil, false
+	}
+
+	pc, tc := p, t
+	if !caseSensitive {
+		pc = toLowerRunes(p)
+		tc = toLowerRunes(t)
+	}
+
+	n, m := len(pc), len(tc)
+	bonus := make([]int, m)
+	for j := range t {
+		bonus[j] = fuzzyBoundaryBonus(t, j)
+	}
+
+	// H[i][j] is the best score aligning pattern[:i+1] with pattern[i]
+	// matched exactly at text[j]; C[i][j] is the length of the consecutive
+	// run of matches ending there. back[i][j] records the text index
+	// pattern[i-1] matched at, for backtracking the final positions.
+	H := make([][]int, n)
+	C := make([][]int, n)
+	back := make([][]int, n)
+	for i := range H {
+		H[i] = make([]int, m)
+		C[i] = make([]int, m)
+		back[i] = make([]int, m)
+		for j := range H[i] {
+			H[i][j] = fuzzyNegInf
+			back[i][j] = -1
+		}
+	}
+
+	for i := 0; i < n; i++ {
+		carry, carryFrom := fuzzyNegInf, -1
+		for j := i; j < m; j++ {
+			// A gap-eligible predecessor enters the carry window once it's
+			// at least one position behind j (gap length >= 1).
+			if i > 0 && j >= 2 && H[i-1][j-2] > fuzzyNegInf {
+				candidate := H[i-1][j-2] + fuzzyScoreGapStart
+				if carry == fuzzyNegInf || candidate > carry+fuzzyScoreGapExtension {
+					carry, carryFrom = candidate, j-2
+				} else {
+					carry += fuzzyScoreGapExtension
+				}
+			} else if carry > fuzzyNegInf {
+				carry += fuzzyScoreGapExtension
+			}
+
+			if tc[j] != pc[i] {
+				continue
+			}
+
+			best, bestFrom, run := fuzzyNegInf, -1, 1
+			if i == 0 {
+				best = fuzzyScoreMatch + bonus[j]
+				if j == 0 {
+					best += fuzzyBonusFirstChar
+				}
+			} else {
+				if j > 0 && H[i-1][j-1] > fuzzyNegInf {
+					b := bonus[j]
+					consecRun := C[i-1][j-1] + 1
+					if consecRun > 1 {
+						b += fuzzyBonusConsecutive
+					}
+					if cand := H[i-1][j-1] + fuzzyScoreMatch + b; cand > best {
+						best, bestFrom, run = cand, j-1, consecRun
+					}
+				}
+				if carry > fuzzyNegInf {
+					if cand := carry + fuzzyScoreMatch + bonus[j]; cand > best {
+						best, bestFrom, run = cand, carryFrom, 1
+					}
+				}
+			}
+			if best > fuzzyNegInf {
+				H[i][j] = best
+				C[i][j] = run
+				back[i][j] = bestFrom
+			}
+		}
+	}
+
+	bestJ, bestScore := -1, fuzzyNegInf
+	for j := 0; j < m; j++ {
+		if H[n-1][j] > bestScore {
+			bestScore, bestJ = H[n-1][j], j
+		}
+	}
+	if bestJ == -1 {
+		return 0, nil, false
+	}
+
+	positions = make([]int, n)
+	j := bestJ
+	for i := n - 1; i >= 0; i-- {
+		positions[i] = j
+		j = back[i][j]
+	}
+	return bestScore, positions, true
+}
+
+func toLowerRunes(rs []rune) []rune {
+	out := make([]rune, len(rs))
+	for i, r := range rs {
+		out[i] = unicode.ToLower(r)
+	}
+	return out
+}
+
+// fuzzyBoundaryBonus rewards a match starting a new "word": position 0, or
+// immediately after a separator, or a lowercase→uppercase transition.
+func fuzzyBoundaryBonus(t []rune, j int) int {
+	if j == 0 {
+		return fuzzyBonusBoundary
+	}
+	switch t[j-1] {
+	case '/', '_', '-', '.', ' ':
+		return fuzzyBonusBoundary
+	}
+	if unicode.IsLower(t[j-1]) && unicode.IsUpper(t[j]) {
+		return fuzzyBonusCamel
+	}
+	return 0
+}
@@ -0,0 +1,121 @@
+package search
+
+import (
+	"ctchen222/chronoflow/internal/domain"
+)
+
+// Document is the subset of a todo's fields the index needs in order to
+// score and filter it. It is rebuilt from a domain.Todo whenever that todo
+// changes.
+type Document struct {
+	UID      string
+	DateKey  string
+	Title    string
+	Desc     string
+	Priority domain.Priority
+	Complete bool
+	Tokens   []string
+}
+
+// posting records how many times a token occurs in one document.
+type posting struct {
+	docUID string
+	freq   int
+}
+
+// Index is an in-memory inverted index over todo titles and descriptions,
+// incrementally maintained as todos are added, edited, or removed (see
+// Upsert and Delete). It is not safe for concurrent use; callers share the
+// same single-goroutine discipline TodoService already relies on.
+type Index struct {
+	postings    map[string][]posting
+	docs        map[string]Document
+	totalTokens int
+}
+
+// NewIndex creates an empty index.
+func NewIndex() *Index {
+	return &Index{
+		postings: make(map[string][]posting),
+		docs:     make(map[string]Document),
+	}
+}
+
+// Upsert (re)indexes a single todo, replacing any previous entry for the
+// same UID. This is the incremental hook TodoService calls from its
+// mutating methods (Add, Update, ToggleComplete, ...) instead of rebuilding
+// the whole index on every change.
+func (idx *Index) Upsert(dateKey string, todo domain.Todo) {
+	idx.Delete(todo.UID)
+
+	tokens := append(Tokenize(todo.Title), Tokenize(todo.Desc)...)
+	doc := Document{
+		UID:      todo.UID,
+		DateKey:  dateKey,
+		Title:    todo.Title,
+		Desc:     todo.Desc,
+		Priority: todo.Priority,
+		Complete: todo.Complete,
+		Tokens:   tokens,
+	}
+	idx.docs[todo.UID] = doc
+	idx.totalTokens += len(tokens)
+
+	freqs := make(map[string]int)
+	for _, tok := range tokens {
+		freqs[tok]++
+	}
+	for tok, freq := range freqs {
+		idx.postings[tok] = append(idx.postings[tok], posting{docUID: todo.UID, freq: freq})
+	}
+}
+
+// Delete removes a todo from the index, if present. It is a no-op for an
+// unknown UID.
+func (idx *Index) Delete(uid string) {
+	doc, ok := idx.docs[uid]
+	if !ok {
+		return
+	}
+	delete(idx.docs, uid)
+	idx.totalTokens -= len(doc.Tokens)
+
+	seen := make(map[string]bool, len(doc.Tokens))
+	for _, tok := range doc.Tokens {
+		if seen[tok] {
+			continue
+		}
+		seen[tok] = true
+
+		remaining := idx.postings[tok][:0]
+		for _, p := range idx.postings[tok] {
+			if p.docUID != uid {
+				remaining = append(remaining, p)
+			}
+		}
+		if len(remaining) == 0 {
+			delete(idx.postings, tok)
+		} else {
+			idx.postings[tok] = remaining
+		}
+	}
+}
+
+// Rebuild discards the current index and re-indexes every todo in todos
+// (keyed by date string, as returned by repository.TodoRepository.GetAll).
+// Used at startup when no usable on-disk snapshot is found.
+func (idx *Index) Rebuild(todos map[string][]domain.Todo) {
+	idx.postings = make(map[string][]posting)
+	idx.docs = make(map[string]Document)
+	idx.totalTokens = 0
+	for dateKey, items := range todos {
+		for _, td := range items {
+			idx.Upsert(dateKey, td)
+		}
+	}
+}
+
+// Len reports how many documents are currently indexed.
+func (idx *Index) Len() int {
+	return len(idx.docs)
+}
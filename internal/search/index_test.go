@@ -0,0 +1,192 @@
+package search
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	"ctchen222/chronoflow/internal/domain"
+)
+
+func mustParse(t *testing.T, s string) time.Time {
+	t.Helper()
+	d, err := time.Parse("2006-01-02", s)
+	if err != nil {
+		t.Fatalf("parse %q: %v", s, err)
+	}
+	return d
+}
+
+func TestTokenize(t *testing.T) {
+	got := Tokenize("Review the Quarterly Report!")
+	want := []string{"review", "quarterly", "report"}
+	if len(got) != len(want) {
+		t.Fatalf("Tokenize = %v, want %v", got, want)
+	}
+	for i, tok := range want {
+		if got[i] != tok {
+			t.Errorf("Tokenize[%d] = %q, want %q", i, got[i], tok)
+		}
+	}
+}
+
+func TestIndexSearchRanksBoundaryMatchHigher(t *testing.T) {
+	idx := NewIndex()
+	idx.Upsert("2026-01-10", domain.Todo{UID: "a", Title: "Project kickoff meeting"})
+	idx.Upsert("2026-01-11", domain.Todo{UID: "b", Title: "Reprojecting the timeline"})
+	idx.Upsert("2026-01-12", domain.Todo{UID: "c", Title: "Buy groceries"})
+
+	results := idx.Search(ParseQuery("project"), mustParse(t, "2026-01-10"), false)
+	if len(results) != 2 {
+		t.Fatalf("got %d results, want 2: %+v", len(results), results)
+	}
+	if results[0].UID != "a" {
+		t.Errorf("expected doc %q (word-boundary match) ranked first, got %q", "a", results[0].UID)
+	}
+}
+
+func TestIndexSearchTitleFilterAllowsSubstringMatch(t *testing.T) {
+	idx := NewIndex()
+	idx.Upsert("2026-01-10", domain.Todo{UID: "a", Title: "Reprojecting the timeline"})
+	idx.Upsert("2026-01-11", domain.Todo{UID: "b", Title: "Buy groceries", Desc: "project supplies"})
+
+	results := idx.Search(ParseQuery("title:project"), mustParse(t, "2026-01-10"), false)
+	if len(results) != 1 || results[0].UID != "a" {
+		t.Fatalf("title: filter got %+v, want a single substring match on doc a", results)
+	}
+}
+
+func TestIndexSearchCaseSensitivity(t *testing.T) {
+	idx := NewIndex()
+	idx.Upsert("2026-01-10", domain.Todo{UID: "a", Title: "Fix API docs"})
+
+	if got := idx.Search(ParseQuery("api"), mustParse(t, "2026-01-10"), false); len(got) != 1 {
+		t.Errorf("case-insensitive search got %+v, want a single match", got)
+	}
+	if got := idx.Search(ParseQuery("api"), mustParse(t, "2026-01-10"), true); len(got) != 0 {
+		t.Errorf("case-sensitive search got %+v, want no match for differing case", got)
+	}
+	if got := idx.Search(ParseQuery("API"), mustParse(t, "2026-01-10"), true); len(got) != 1 {
+		t.Errorf("case-sensitive search got %+v, want a single match for exact case", got)
+	}
+}
+
+func TestIndexSearchPrefixQuery(t *testing.T) {
+	idx := NewIndex()
+	idx.Upsert("2026-01-10", domain.Todo{UID: "a", Title: "Projector bulb replacement"})
+	idx.Upsert("2026-01-11", domain.Todo{UID: "b", Title: "Buy groceries"})
+
+	results := idx.Search(ParseQuery("proj*"), mustParse(t, "2026-01-10"), false)
+	if len(results) != 1 || results[0].UID != "a" {
+		t.Fatalf("prefix query got %+v, want a single match on doc a", results)
+	}
+}
+
+func TestIndexSearchPhraseQuery(t *testing.T) {
+	idx := NewIndex()
+	idx.Upsert("2026-01-10", domain.Todo{UID: "a", Title: "Quarterly review meeting"})
+	idx.Upsert("2026-01-11", domain.Todo{UID: "b", Title: "Review the quarterly numbers"})
+
+	results := idx.Search(ParseQuery(`"quarterly review"`), mustParse(t, "2026-01-10"), false)
+	if len(results) != 1 || results[0].UID != "a" {
+		t.Fatalf("phrase query got %+v, want a single match on doc a", results)
+	}
+}
+
+func TestIndexSearchFieldFilters(t *testing.T) {
+	idx := NewIndex()
+	idx.Upsert("2026-01-10", domain.Todo{UID: "a", Title: "Submit report", Priority: domain.PriorityHigh, Complete: true})
+	idx.Upsert("2026-01-10", domain.Todo{UID: "b", Title: "Submit report", Priority: domain.PriorityLow, Complete: false})
+	idx.Upsert("2026-02-15", domain.Todo{UID: "c", Title: "Submit report", Priority: domain.PriorityHigh, Complete: true})
+
+	results := idx.Search(ParseQuery("submit report is:done p:high date:2026-01"), mustParse(t, "2026-01-10"), false)
+	if len(results) != 1 || results[0].UID != "a" {
+		t.Fatalf("filtered query got %+v, want a single match on doc a", results)
+	}
+}
+
+func TestIndexSearchTieBreaksByDateProximity(t *testing.T) {
+	idx := NewIndex()
+	idx.Upsert("2026-01-01", domain.Todo{UID: "far", Title: "errand"})
+	idx.Upsert("2026-01-09", domain.Todo{UID: "near", Title: "errand"})
+
+	results := idx.Search(ParseQuery("errand"), mustParse(t, "2026-01-10"), false)
+	if len(results) != 2 || results[0].UID != "near" {
+		t.Fatalf("got %+v, want doc %q (closer to today) ranked first", results, "near")
+	}
+}
+
+func TestIndexUpsertReplacesPreviousVersion(t *testing.T) {
+	idx := NewIndex()
+	idx.Upsert("2026-01-10", domain.Todo{UID: "a", Title: "old title"})
+	idx.Upsert("2026-01-10", domain.Todo{UID: "a", Title: "new wording"})
+
+	if got := idx.Search(ParseQuery("old"), mustParse(t, "2026-01-10"), false); len(got) != 0 {
+		t.Errorf("stale token %q still matches after Upsert: %+v", "old", got)
+	}
+	if got := idx.Search(ParseQuery("wording"), mustParse(t, "2026-01-10"), false); len(got) != 1 {
+		t.Errorf("updated token %q does not match after Upsert: %+v", "wording", got)
+	}
+}
+
+func TestIndexDeleteRemovesDocument(t *testing.T) {
+	idx := NewIndex()
+	idx.Upsert("2026-01-10", domain.Todo{UID: "a", Title: "temporary task"})
+	idx.Delete("a")
+
+	if got := idx.Search(ParseQuery("temporary"), mustParse(t, "2026-01-10"), false); len(got) != 0 {
+		t.Errorf("deleted doc still matches: %+v", got)
+	}
+	if idx.Len() != 0 {
+		t.Errorf("Len() = %d, want 0 after Delete", idx.Len())
+	}
+}
+
+func TestIndexSaveLoadRoundTrip(t *testing.T) {
+	idx := NewIndex()
+	idx.Upsert("2026-01-10", domain.Todo{UID: "a", Title: "Renew passport", Priority: domain.PriorityMedium})
+
+	path := t.TempDir() + "/index.json"
+	if err := idx.Save(path); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+
+	loaded := NewIndex()
+	ok, err := loaded.Load(path)
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	if !ok {
+		t.Fatal("Load reported ok=false for a freshly saved snapshot")
+	}
+
+	results := loaded.Search(ParseQuery("passport"), mustParse(t, "2026-01-10"), false)
+	if len(results) != 1 || results[0].UID != "a" {
+		t.Fatalf("loaded index search got %+v, want a single match on doc a", results)
+	}
+}
+
+func TestIndexLoadRejectsVersionMismatch(t *testing.T) {
+	path := t.TempDir() + "/index.json"
+	if err := NewIndex().Save(path); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+
+	loaded := NewIndex()
+	loaded.docs = nil // sentinel so we can tell whether Load mutated it
+	snapWithBadVersion := `{"version":999,"docs":{}}`
+	if err := os.WriteFile(path, []byte(snapWithBadVersion), 0644); err != nil {
+		t.Fatalf("WriteFile: %v", err)
+	}
+
+	ok, err := loaded.Load(path)
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	if ok {
+		t.Error("Load reported ok=true for a mismatched version, want false so the caller rebuilds")
+	}
+	if loaded.docs != nil {
+		t.Error("Load mutated the index for a mismatched version, want it left untouched")
+	}
+}
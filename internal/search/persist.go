@@ -0,0 +1,76 @@
+package search
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+)
+
+// indexVersion is bumped whenever the on-disk snapshot format changes in an
+// incompatible way; Load rejects a snapshot written by a different version
+// so the caller falls back to Rebuild.
+const indexVersion = 1
+
+// snapshot is the on-disk representation of an Index: just the documents,
+// since postings are cheap to rebuild from them on Load.
+type snapshot struct {
+	Version int                 `json:"version"`
+	Docs    map[string]Document `json:"docs"`
+}
+
+// Save writes the index to filePath as JSON.
+func (idx *Index) Save(filePath string) error {
+	dir := filepath.Dir(filePath)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return err
+	}
+
+	snap := snapshot{Version: indexVersion, Docs: idx.docs}
+	data, err := json.MarshalIndent(snap, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filePath, data, 0644)
+}
+
+// Load reads a previously saved index from filePath. ok is false if the
+// file is missing, empty, or was written by an incompatible version; the
+// caller should Rebuild from the source of truth in that case.
+func (idx *Index) Load(filePath string) (ok bool, err error) {
+	data, err := os.ReadFile(filePath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return false, nil
+		}
+		return false, err
+	}
+	if len(data) == 0 {
+		return false, nil
+	}
+
+	var snap snapshot
+	if err := json.Unmarshal(data, &snap); err != nil {
+		return false, err
+	}
+	if snap.Version != indexVersion {
+		return false, nil
+	}
+
+	idx.postings = make(map[string][]posting)
+	idx.docs = make(map[string]Document)
+	idx.totalTokens = 0
+	for uid, doc := range snap.Docs {
+		idx.docs[uid] = doc
+		idx.totalTokens += len(doc.Tokens)
+
+		freqs := make(map[string]int)
+		for _, tok := range doc.Tokens {
+			freqs[tok]++
+		}
+		for tok, freq := range freqs {
+			idx.postings[tok] = append(idx.postings[tok], posting{docUID: uid, freq: freq})
+		}
+	}
+
+	return true, nil
+}
@@ -0,0 +1,141 @@
+package search
+
+import (
+	"strings"
+
+	"ctchen222/chronoflow/internal/domain"
+)
+
+// Query is a parsed search expression, ready to be matched against an
+// Index. Build one with ParseQuery rather than constructing it directly.
+type Query struct {
+	// Terms are plain keywords. Any Terms make every document a candidate;
+	// ranking comes from fuzzy-matching FuzzyPattern, not from the Terms.
+	Terms []string
+	// Prefixes are terms written as "proj*", matched against any indexed
+	// token sharing that prefix.
+	Prefixes []string
+	// Phrases are quoted multi-word sequences ("quarterly review"); a
+	// document must contain the exact token sequence to match.
+	Phrases [][]string
+	// TitleOnly restricts Terms/Prefixes/Phrases to the title field,
+	// set by a title:... filter.
+	TitleOnly bool
+	// DateFilter restricts results to date keys with this prefix
+	// ("2025-11" or "2025-11-03"), set by a date:... filter.
+	DateFilter string
+	// Done, if non-nil, restricts to completed (true) or incomplete
+	// (false) todos, set by an is:done / is:pending filter.
+	Done *bool
+	// Priority, if non-nil, restricts to that exact priority, set by a
+	// p:... filter.
+	Priority *domain.Priority
+	// FuzzyPattern is the literal (untokenized, original-case) free text the
+	// user typed, excluding field filters and phrase quotes — the pattern
+	// FuzzyMatch scores documents against.
+	FuzzyPattern string
+}
+
+// empty reports whether the query has no term-like clauses, i.e. it's
+// either blank or filters-only.
+func (q Query) empty() bool {
+	return len(q.Terms) == 0 && len(q.Prefixes) == 0 && len(q.Phrases) == 0
+}
+
+// ParseQuery parses raw search syntax: bare words (fuzzy-matched terms), prefix*,
+// "exact phrases", and field filters (title:, date:, is:, p:). Unrecognized
+// "field:value" tokens are treated as plain terms.
+func ParseQuery(raw string) Query {
+	var q Query
+	var fuzzyFields []string
+	for _, field := range splitFields(raw) {
+		switch {
+		case len(field) >= 2 && strings.HasPrefix(field, `"`) && strings.HasSuffix(field, `"`):
+			trimmed := strings.Trim(field, `"`)
+			if phrase := Tokenize(trimmed); len(phrase) > 0 {
+				q.Phrases = append(q.Phrases, phrase)
+				fuzzyFields = append(fuzzyFields, trimmed)
+			}
+		case strings.HasPrefix(field, "title:"):
+			q.TitleOnly = true
+			rest := strings.TrimPrefix(field, "title:")
+			q.Terms = append(q.Terms, Tokenize(rest)...)
+			fuzzyFields = append(fuzzyFields, rest)
+		case strings.HasPrefix(field, "date:"):
+			q.DateFilter = strings.TrimPrefix(field, "date:")
+		case strings.HasPrefix(field, "is:"):
+			if done, ok := parseDoneFilter(strings.TrimPrefix(field, "is:")); ok {
+				q.Done = &done
+			}
+		case strings.HasPrefix(field, "p:"):
+			if pr, ok := parsePriority(strings.TrimPrefix(field, "p:")); ok {
+				q.Priority = &pr
+			}
+		case len(field) > 1 && strings.HasSuffix(field, "*"):
+			rest := strings.TrimSuffix(field, "*")
+			if prefix := strings.ToLower(rest); prefix != "" {
+				q.Prefixes = append(q.Prefixes, prefix)
+			}
+			fuzzyFields = append(fuzzyFields, rest)
+		default:
+			q.Terms = append(q.Terms, Tokenize(field)...)
+			fuzzyFields = append(fuzzyFields, field)
+		}
+	}
+	q.FuzzyPattern = strings.Join(fuzzyFields, " ")
+	return q
+}
+
+func parseDoneFilter(s string) (done bool, ok bool) {
+	switch s {
+	case "done", "complete", "completed":
+		return true, true
+	case "pending", "open", "incomplete":
+		return false, true
+	}
+	return false, false
+}
+
+func parsePriority(s string) (domain.Priority, bool) {
+	switch strings.ToLower(s) {
+	case "high", "h":
+		return domain.PriorityHigh, true
+	case "medium", "med", "m":
+		return domain.PriorityMedium, true
+	case "low", "l":
+		return domain.PriorityLow, true
+	case "none", "n":
+		return domain.PriorityNone, true
+	}
+	return domain.PriorityNone, false
+}
+
+// splitFields splits raw on whitespace, except inside double quotes, so a
+// quoted phrase survives as a single field.
+func splitFields(raw string) []string {
+	var fields []string
+	var b strings.Builder
+	inQuotes := false
+
+	flush := func() {
+		if b.Len() > 0 {
+			fields = append(fields, b.String())
+			b.Reset()
+		}
+	}
+
+	for _, r := range raw {
+		switch {
+		case r == '"':
+			inQuotes = !inQuotes
+			b.WriteRune(r)
+		case r == ' ' && !inQuotes:
+			flush()
+		default:
+			b.WriteRune(r)
+		}
+	}
+	flush()
+
+	return fields
+}
@@ -0,0 +1,217 @@
+package search
+
+import (
+	"sort"
+	"strings"
+	"time"
+)
+
+// descWeight scales a description match's contribution to Result.Score
+// relative to a title match, so a title hit always outranks a description
+// hit of the same quality.
+const descWeight = 0.4
+
+// Result is a single scored, filtered match from Index.Search.
+type Result struct {
+	UID            string
+	DateKey        string
+	Score          float64
+	MatchPositions []int // rune indices into Title, for highlighting
+}
+
+// Search finds documents matching q's field filters (title:/date:/is:/p:,
+// plus phrase and prefix* clauses) and, among those, fuzzy-matches
+// q.FuzzyPattern against each document's title and description using
+// FuzzyMatch — modeled on fzf's v2 algorithm, rewarding matches at word
+// boundaries and consecutive runs, penalizing gaps. A description match adds
+// its score scaled down by descWeight, so a description-only match scores
+// lower than a title match of the same quality. Results are ordered by
+// score descending, ties broken by date proximity to today. A query with
+// only field filters and no free text matches every filtered candidate with
+// score 0, ordered purely by date proximity.
+func (idx *Index) Search(q Query, today time.Time, caseSensitive bool) []Result {
+	candidates := idx.candidateUIDs(q)
+	if len(candidates) == 0 {
+		return nil
+	}
+
+	results := make([]Result, 0, len(candidates))
+	for uid := range candidates {
+		doc, ok := idx.docs[uid]
+		if !ok || !q.matches(doc) {
+			continue
+		}
+
+		if q.FuzzyPattern == "" {
+			results = append(results, Result{UID: uid, DateKey: doc.DateKey})
+			continue
+		}
+
+		titleScore, positions, titleOK := FuzzyMatch(q.FuzzyPattern, doc.Title, caseSensitive)
+		if q.TitleOnly {
+			if !titleOK {
+				continue
+			}
+			results = append(results, Result{
+				UID:            uid,
+				DateKey:        doc.DateKey,
+				Score:          float64(titleScore),
+				MatchPositions: positions,
+			})
+			continue
+		}
+
+		descScore, _, descOK := FuzzyMatch(q.FuzzyPattern, doc.Desc, caseSensitive)
+		if !titleOK && !descOK {
+			continue
+		}
+
+		score := float64(titleScore)
+		if descOK {
+			score += float64(descScore) * descWeight
+		}
+		results = append(results, Result{
+			UID:            uid,
+			DateKey:        doc.DateKey,
+			Score:          score,
+			MatchPositions: positions,
+		})
+	}
+
+	sort.Slice(results, func(i, j int) bool {
+		if results[i].Score != results[j].Score {
+			return results[i].Score > results[j].Score
+		}
+		return dateDistance(results[i].DateKey, today) < dateDistance(results[j].DateKey, today)
+	})
+
+	return results
+}
+
+// candidateUIDs returns the set of documents that could possibly match q: a
+// filters-only query (no terms/prefixes/phrases) candidates every indexed
+// document. Terms are free text, ultimately scored by FuzzyMatch in Search,
+// so they candidate every document rather than just exact postings matches
+// (a query like "project" must still consider a title like "Reprojecting the
+// timeline" that contains it only as a substring). Prefixes and Phrases keep
+// their own precise candidate generation, since those clauses promise exact
+// prefix/sequence matches rather than fuzzy ones.
+func (idx *Index) candidateUIDs(q Query) map[string]bool {
+	if q.empty() {
+		all := make(map[string]bool, len(idx.docs))
+		for uid := range idx.docs {
+			all[uid] = true
+		}
+		return all
+	}
+
+	candidates := make(map[string]bool)
+	if len(q.Terms) > 0 {
+		for uid := range idx.docs {
+			candidates[uid] = true
+		}
+	}
+	for _, prefix := range q.Prefixes {
+		for tok, postings := range idx.postings {
+			if strings.HasPrefix(tok, prefix) {
+				for _, p := range postings {
+					candidates[p.docUID] = true
+				}
+			}
+		}
+	}
+	for _, phrase := range q.Phrases {
+		for uid, doc := range idx.docs {
+			if containsPhrase(doc.Tokens, phrase) {
+				candidates[uid] = true
+			}
+		}
+	}
+	return candidates
+}
+
+// matches reports whether doc satisfies every filter on q (date/is/p, plus
+// title: restricting the term match to the title field). It does not
+// re-check term/prefix/phrase membership for the general case, since
+// candidateUIDs already selected on that basis. When q carries a
+// FuzzyPattern, title: restriction is instead enforced by Search scoring
+// only doc.Title (see TitleOnly handling there) rather than by the exact
+// matchesTokens gate here, so a title: filter benefits from the same
+// substring fuzzy matching as an unfiltered query.
+func (q Query) matches(doc Document) bool {
+	if q.DateFilter != "" && !strings.HasPrefix(doc.DateKey, q.DateFilter) {
+		return false
+	}
+	if q.Done != nil && doc.Complete != *q.Done {
+		return false
+	}
+	if q.Priority != nil && doc.Priority != *q.Priority {
+		return false
+	}
+	if q.TitleOnly && !q.empty() && q.FuzzyPattern == "" {
+		titleTokens := Tokenize(doc.Title)
+		if !matchesTokens(titleTokens, q) {
+			return false
+		}
+	}
+	return true
+}
+
+func matchesTokens(tokens []string, q Query) bool {
+	set := make(map[string]bool, len(tokens))
+	for _, t := range tokens {
+		set[t] = true
+	}
+	for _, term := range q.Terms {
+		if set[term] {
+			return true
+		}
+	}
+	for _, prefix := range q.Prefixes {
+		for t := range set {
+			if strings.HasPrefix(t, prefix) {
+				return true
+			}
+		}
+	}
+	for _, phrase := range q.Phrases {
+		if containsPhrase(tokens, phrase) {
+			return true
+		}
+	}
+	return false
+}
+
+func containsPhrase(tokens, phrase []string) bool {
+	if len(phrase) == 0 || len(phrase) > len(tokens) {
+		return false
+	}
+	for i := 0; i+len(phrase) <= len(tokens); i++ {
+		match := true
+		for j, pt := range phrase {
+			if tokens[i+j] != pt {
+				match = false
+				break
+			}
+		}
+		if match {
+			return true
+		}
+	}
+	return false
+}
+
+// dateDistance returns the absolute number of days between dateKey
+// ("2006-01-02") and today, used to break score ties. An unparseable
+// dateKey sorts last.
+func dateDistance(dateKey string, today time.Time) int {
+	d, err := time.Parse("2006-01-02", dateKey)
+	if err != nil {
+		return 1 << 30
+	}
+	days := int(d.Sub(today).Hours() / 24)
+	if days < 0 {
+		days = -days
+	}
+	return days
+}
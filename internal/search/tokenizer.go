@@ -0,0 +1,52 @@
+// Package search provides an in-memory, incrementally maintained full-text
+// index over todo titles and descriptions, with fzf-style fuzzy ranking and a small
+// query syntax (bare terms, prefix*, "exact phrases", and field filters).
+// It replaces TodoService's earlier O(N) substring scan.
+package search
+
+import (
+	"strings"
+	"unicode"
+)
+
+// stopWords are common English words excluded from the index since they
+// carry little discriminating signal and would otherwise dominate postings
+// for nearly every document.
+var stopWords = map[string]bool{
+	"a": true, "an": true, "and": true, "are": true, "as": true, "at": true,
+	"be": true, "by": true, "for": true, "from": true, "has": true, "he": true,
+	"in": true, "is": true, "it": true, "its": true, "of": true, "on": true,
+	"or": true, "that": true, "the": true, "to": true, "was": true, "were": true,
+	"will": true, "with": true,
+}
+
+// Tokenize splits text into lowercased, stopword-filtered tokens. Splitting
+// happens on any rune that isn't a letter or digit, which keeps it
+// reasonable for unicode titles without needing a real language-aware
+// segmenter.
+func Tokenize(text string) []string {
+	var tokens []string
+	var b strings.Builder
+
+	flush := func() {
+		if b.Len() == 0 {
+			return
+		}
+		tok := b.String()
+		b.Reset()
+		if !stopWords[tok] {
+			tokens = append(tokens, tok)
+		}
+	}
+
+	for _, r := range text {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			b.WriteRune(unicode.ToLower(r))
+		} else {
+			flush()
+		}
+	}
+	flush()
+
+	return tokens
+}
@@ -0,0 +1,172 @@
+package secrets
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+)
+
+// fallbackDir returns the directory the encrypted-file fallback stores its
+// master key and credential store in, mirroring cmd/chronoflow's
+// ~/.chronoflow data directory convention.
+func fallbackDir() (string, error) {
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("secrets: resolve home directory: %w", err)
+	}
+	dir := filepath.Join(homeDir, ".chronoflow")
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return "", fmt.Errorf("secrets: create data directory: %w", err)
+	}
+	return dir, nil
+}
+
+// masterKey loads the local AES-256 key used to encrypt fallback credential
+// files, generating and persisting one on first use. The key is random
+// rather than passphrase-derived: the login flow this fallback serves
+// never collects a passphrase, only the remote account's own credentials.
+func masterKey() ([]byte, error) {
+	dir, err := fallbackDir()
+	if err != nil {
+		return nil, err
+	}
+	path := filepath.Join(dir, "secrets.key")
+
+	if key, err := os.ReadFile(path); err == nil && len(key) == 32 {
+		return key, nil
+	}
+
+	key := make([]byte, 32)
+	if _, err := io.ReadFull(rand.Reader, key); err != nil {
+		return nil, fmt.Errorf("secrets: generate master key: %w", err)
+	}
+	if err := os.WriteFile(path, key, 0o600); err != nil {
+		return nil, fmt.Errorf("secrets: persist master key: %w", err)
+	}
+	return key, nil
+}
+
+// fallbackStore is the on-disk shape of the file fallback: one AES-GCM
+// sealed (nonce-prefixed) blob per profile.
+type fallbackStore map[string][]byte
+
+func fallbackPath(dir string) string {
+	return filepath.Join(dir, "secrets.enc.json")
+}
+
+func loadStore() (store fallbackStore, path string, err error) {
+	dir, err := fallbackDir()
+	if err != nil {
+		return nil, "", err
+	}
+	path = fallbackPath(dir)
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return fallbackStore{}, path, nil
+		}
+		return nil, "", fmt.Errorf("secrets: read %s: %w", path, err)
+	}
+	store = make(fallbackStore)
+	if err := json.Unmarshal(data, &store); err != nil {
+		return nil, "", fmt.Errorf("secrets: decode %s: %w", path, err)
+	}
+	return store, path, nil
+}
+
+func saveStore(path string, store fallbackStore) error {
+	data, err := json.Marshal(store)
+	if err != nil {
+		return fmt.Errorf("secrets: encode fallback store: %w", err)
+	}
+	return os.WriteFile(path, data, 0o600)
+}
+
+func seal(key, plaintext []byte) ([]byte, error) {
+	gcm, err := newGCM(key)
+	if err != nil {
+		return nil, err
+	}
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return nil, fmt.Errorf("secrets: generate nonce: %w", err)
+	}
+	return gcm.Seal(nonce, nonce, plaintext, nil), nil
+}
+
+func open(key, sealed []byte) ([]byte, error) {
+	gcm, err := newGCM(key)
+	if err != nil {
+		return nil, err
+	}
+	if len(sealed) < gcm.NonceSize() {
+		return nil, errors.New("secrets: corrupt fallback entry")
+	}
+	nonce, ciphertext := sealed[:gcm.NonceSize()], sealed[gcm.NonceSize():]
+	return gcm.Open(nil, nonce, ciphertext, nil)
+}
+
+func newGCM(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, fmt.Errorf("secrets: init cipher: %w", err)
+	}
+	gcm, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, fmt.Errorf("secrets: init GCM: %w", err)
+	}
+	return gcm, nil
+}
+
+func storeFile(profile string, plaintext []byte) error {
+	key, err := masterKey()
+	if err != nil {
+		return err
+	}
+	sealed, err := seal(key, plaintext)
+	if err != nil {
+		return err
+	}
+
+	store, path, err := loadStore()
+	if err != nil {
+		return err
+	}
+	store[profile] = sealed
+	return saveStore(path, store)
+}
+
+func loadFile(profile string) ([]byte, error) {
+	key, err := masterKey()
+	if err != nil {
+		return nil, err
+	}
+	store, _, err := loadStore()
+	if err != nil {
+		return nil, err
+	}
+	sealed, ok := store[profile]
+	if !ok {
+		return nil, fmt.Errorf("secrets: no fallback entry for %q", profile)
+	}
+	return open(key, sealed)
+}
+
+func deleteFile(profile string) error {
+	store, path, err := loadStore()
+	if err != nil {
+		return err
+	}
+	if _, ok := store[profile]; !ok {
+		return nil
+	}
+	delete(store, profile)
+	return saveStore(path, store)
+}
@@ -0,0 +1,100 @@
+// Package secrets stores remote-sync account credentials (CalDAV and any
+// future backend) outside chronoflow's own JSON files. Credentials are
+// kept in the OS keyring where one is available, falling back to a
+// locally encrypted file (see fallback.go) on platforms without one, e.g.
+// headless Linux or CI.
+package secrets
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/zalando/go-keyring"
+)
+
+// serviceName is the keyring service chronoflow's entries are stored under.
+const serviceName = "chronoflow"
+
+// credentials is the JSON blob stored as the keyring "password" for a
+// profile, bundling the username and password into the single secret slot
+// the keyring API offers.
+type credentials struct {
+	User string `json:"user"`
+	Pass string `json:"pass"`
+}
+
+// Store saves user/pass under profile (e.g. "caldav"), preferring the OS
+// keyring and falling back to an encrypted local file if none is available.
+func Store(profile, user, pass string) error {
+	data, err := json.Marshal(credentials{User: user, Pass: pass})
+	if err != nil {
+		return fmt.Errorf("secrets: encode credentials for %q: %w", profile, err)
+	}
+	return storeSecret(profile, data)
+}
+
+// Load retrieves the user/pass previously stored under profile, trying the
+// OS keyring first and the encrypted file fallback second.
+func Load(profile string) (user, pass string, err error) {
+	data, err := loadSecret(profile)
+	if err != nil {
+		return "", "", err
+	}
+
+	var creds credentials
+	if err := json.Unmarshal([]byte(data), &creds); err != nil {
+		return "", "", fmt.Errorf("secrets: decode credentials for %q: %w", profile, err)
+	}
+	return creds.User, creds.Pass, nil
+}
+
+// Delete removes any stored credentials for profile from both the keyring
+// and the file fallback. It's not an error for either to have nothing to
+// remove.
+func Delete(profile string) error {
+	if err := keyring.Delete(serviceName, profile); err != nil && err != keyring.ErrNotFound {
+		return fmt.Errorf("secrets: delete %q from keyring: %w", profile, err)
+	}
+	return deleteFile(profile)
+}
+
+// StoreRaw saves an arbitrary opaque secret under profile, for callers
+// whose credential shape isn't the username/password pair Store/Load
+// assume — e.g. internal/sync/google persisting a JSON-encoded OAuth2
+// token, which has no separate "username" to bundle it with.
+func StoreRaw(profile, value string) error {
+	return storeSecret(profile, []byte(value))
+}
+
+// LoadRaw retrieves the secret previously stored under profile via
+// StoreRaw, trying the OS keyring first and the encrypted file fallback
+// second.
+func LoadRaw(profile string) (string, error) {
+	return loadSecret(profile)
+}
+
+// storeSecret is the shared keyring-then-file-fallback write path behind
+// Store and StoreRaw.
+func storeSecret(profile string, data []byte) error {
+	if err := keyring.Set(serviceName, profile, string(data)); err != nil {
+		return storeFile(profile, data)
+	}
+	return nil
+}
+
+// loadSecret is the shared keyring-then-file-fallback read path behind
+// Load and LoadRaw. If the keyring lookup fails, the file fallback's error
+// is surfaced instead of the keyring's: on platforms with no keyring (the
+// fallback's main use case), the keyring error is always "not found" and
+// never the interesting one.
+func loadSecret(profile string) (string, error) {
+	data, keyringErr := keyring.Get(serviceName, profile)
+	if keyringErr != nil {
+		fileData, fileErr := loadFile(profile)
+		if fileErr != nil {
+			return "", fmt.Errorf("secrets: load %q: %w", profile, fileErr)
+		}
+		data = string(fileData)
+	}
+	return data, nil
+}
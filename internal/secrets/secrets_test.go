@@ -0,0 +1,91 @@
+package secrets
+
+import (
+	"testing"
+
+	"github.com/zalando/go-keyring"
+)
+
+func TestStoreLoadRoundTripViaKeyring(t *testing.T) {
+	keyring.MockInit()
+
+	if err := Store("caldav", "alice", "hunter2"); err != nil {
+		t.Fatalf("Store: %v", err)
+	}
+
+	user, pass, err := Load("caldav")
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	if user != "alice" || pass != "hunter2" {
+		t.Errorf("Load = (%q, %q), want (%q, %q)", user, pass, "alice", "hunter2")
+	}
+}
+
+func TestStoreLoadRoundTripViaFileFallback(t *testing.T) {
+	keyring.MockInitWithError(keyring.ErrNotFound)
+	t.Setenv("HOME", t.TempDir())
+
+	if err := Store("caldav", "bob", "s3cret"); err != nil {
+		t.Fatalf("Store: %v", err)
+	}
+
+	user, pass, err := Load("caldav")
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	if user != "bob" || pass != "s3cret" {
+		t.Errorf("Load = (%q, %q), want (%q, %q)", user, pass, "bob", "s3cret")
+	}
+}
+
+func TestStoreRawLoadRawRoundTrip(t *testing.T) {
+	keyring.MockInitWithError(keyring.ErrNotFound)
+	t.Setenv("HOME", t.TempDir())
+
+	token := `{"access_token":"fake","token_type":"Bearer"}`
+	if err := StoreRaw("google", token); err != nil {
+		t.Fatalf("StoreRaw: %v", err)
+	}
+
+	got, err := LoadRaw("google")
+	if err != nil {
+		t.Fatalf("LoadRaw: %v", err)
+	}
+	if got != token {
+		t.Errorf("LoadRaw = %q, want %q", got, token)
+	}
+}
+
+func TestLoadWithNothingStoredReturnsError(t *testing.T) {
+	keyring.MockInitWithError(keyring.ErrNotFound)
+	t.Setenv("HOME", t.TempDir())
+
+	if _, _, err := Load("caldav"); err == nil {
+		t.Error("Load with nothing stored = nil error, want one")
+	}
+}
+
+func TestDeleteRemovesFromFileFallback(t *testing.T) {
+	keyring.MockInitWithError(keyring.ErrNotFound)
+	t.Setenv("HOME", t.TempDir())
+
+	if err := Store("caldav", "carol", "pw"); err != nil {
+		t.Fatalf("Store: %v", err)
+	}
+	if err := Delete("caldav"); err != nil {
+		t.Fatalf("Delete: %v", err)
+	}
+	if _, _, err := Load("caldav"); err == nil {
+		t.Error("Load after Delete = nil error, want one")
+	}
+}
+
+func TestDeleteOfUnknownProfileIsNotAnError(t *testing.T) {
+	keyring.MockInitWithError(keyring.ErrNotFound)
+	t.Setenv("HOME", t.TempDir())
+
+	if err := Delete("never-stored"); err != nil {
+		t.Errorf("Delete of an unknown profile = %v, want nil", err)
+	}
+}
@@ -0,0 +1,48 @@
+package service
+
+import "fmt"
+
+// SortMode selects how TodoService.GetTodosForDate orders sibling todos
+// within the subtask tree.
+type SortMode int
+
+const (
+	// SortManual keeps siblings in storage order, i.e. the order produced
+	// by Add/Reorder/MoveUp/MoveDown. This is the default.
+	SortManual SortMode = iota
+	SortPriority
+	SortDueTime
+	SortTitle
+)
+
+// String returns the config-file representation of the sort mode.
+func (m SortMode) String() string {
+	switch m {
+	case SortPriority:
+		return "priority"
+	case SortDueTime:
+		return "due_time"
+	case SortTitle:
+		return "title"
+	default:
+		return "manual"
+	}
+}
+
+// ParseSortMode parses the config-file representation produced by String.
+// An empty or unrecognized string parses as SortManual, so missing config
+// entries behave the same as an explicit manual sort.
+func ParseSortMode(s string) (SortMode, error) {
+	switch s {
+	case "", "manual":
+		return SortManual, nil
+	case "priority":
+		return SortPriority, nil
+	case "due_time":
+		return SortDueTime, nil
+	case "title":
+		return SortTitle, nil
+	default:
+		return SortManual, fmt.Errorf("service: unknown sort mode %q", s)
+	}
+}
@@ -23,11 +23,25 @@ type Stats struct {
 	CompletedPeriod int
 	OverduePeriod   int
 	PeriodLabel     string
+	// HabitsPeriodComplete counts scheduled-habit completions within the
+	// current period; see HabitCompletionSource.
+	HabitsPeriodComplete int
+}
+
+// HabitCompletionSource supplies the data CalculateStats needs to report
+// habit completion, without StatsCalculator depending on the internal/habits
+// package directly (which in turn depends on this package for TimeProvider).
+type HabitCompletionSource interface {
+	// HabitsForDate returns the habits scheduled for date.
+	HabitsForDate(date time.Time) []domain.Habit
+	// IsDone reports whether a habit was completed on date.
+	IsDone(habitUID string, date time.Time) bool
 }
 
 // StatsCalculator calculates todo statistics
 type StatsCalculator struct {
 	timeProvider TimeProvider
+	leafOnly     bool
 }
 
 // NewStatsCalculator creates a new StatsCalculator
@@ -35,11 +49,39 @@ func NewStatsCalculator(tp TimeProvider) *StatsCalculator {
 	return &StatsCalculator{timeProvider: tp}
 }
 
+// SetLeafOnly controls whether CalculateStats counts every todo or only
+// leaf tasks (todos with no subtasks of their own). Enable this once a
+// todo list uses subtasks, so a parent and its children aren't both
+// counted toward the same total.
+func (sc *StatsCalculator) SetLeafOnly(leafOnly bool) {
+	sc.leafOnly = leafOnly
+}
+
 // CalculateStats calculates statistics for all todos based on view mode and cursor date
 func (sc *StatsCalculator) CalculateStats(
 	todos map[string][]domain.Todo,
 	viewMode ViewMode,
 	cursorDate time.Time,
+) Stats {
+	return sc.calculateStats(todos, nil, viewMode, cursorDate)
+}
+
+// CalculateStatsWithHabits is CalculateStats plus HabitsPeriodComplete,
+// tallied from habitSource for every day in the period.
+func (sc *StatsCalculator) CalculateStatsWithHabits(
+	todos map[string][]domain.Todo,
+	habitSource HabitCompletionSource,
+	viewMode ViewMode,
+	cursorDate time.Time,
+) Stats {
+	return sc.calculateStats(todos, habitSource, viewMode, cursorDate)
+}
+
+func (sc *StatsCalculator) calculateStats(
+	todos map[string][]domain.Todo,
+	habitSource HabitCompletionSource,
+	viewMode ViewMode,
+	cursorDate time.Time,
 ) Stats {
 	today := sc.timeProvider.Today()
 	periodStart, periodEnd, periodLabel := sc.getPeriodBounds(viewMode, cursorDate)
@@ -48,6 +90,16 @@ func (sc *StatsCalculator) CalculateStats(
 		PeriodLabel: periodLabel,
 	}
 
+	if habitSource != nil {
+		for d := periodStart; d.Before(periodEnd); d = d.AddDate(0, 0, 1) {
+			for _, h := range habitSource.HabitsForDate(d) {
+				if habitSource.IsDone(h.UID, d) {
+					stats.HabitsPeriodComplete++
+				}
+			}
+		}
+	}
+
 	for dateKey, items := range todos {
 		date, err := time.Parse("2006-01-02", dateKey)
 		if err != nil {
@@ -58,7 +110,20 @@ func (sc *StatsCalculator) CalculateStats(
 		isPast := date.Before(today)
 		inPeriod := !date.Before(periodStart) && date.Before(periodEnd)
 
+		var parentUIDs map[string]bool
+		if sc.leafOnly {
+			parentUIDs = make(map[string]bool)
+			for _, it := range items {
+				if it.ParentUID != "" {
+					parentUIDs[it.ParentUID] = true
+				}
+			}
+		}
+
 		for _, item := range items {
+			if sc.leafOnly && item.UID != "" && parentUIDs[item.UID] {
+				continue // only leaf tasks count toward totals
+			}
 			stats.TotalAll++
 			if item.Complete {
 				stats.CompletedAll++
@@ -80,6 +145,14 @@ func (sc *StatsCalculator) CalculateStats(
 	return stats
 }
 
+// VisibleRange returns the [start, end) period a calendar showing viewMode
+// at cursorDate currently spans, for callers (e.g. recurring-todo expansion)
+// that need the same window the stats period uses but don't need its label.
+func (sc *StatsCalculator) VisibleRange(viewMode ViewMode, cursorDate time.Time) (start, end time.Time) {
+	start, end, _ = sc.getPeriodBounds(viewMode, cursorDate)
+	return start, end
+}
+
 // getPeriodBounds returns the start and end dates for the current period
 func (sc *StatsCalculator) getPeriodBounds(viewMode ViewMode, cursorDate time.Time) (start, end time.Time, label string) {
 	if viewMode == WeekView {
@@ -125,6 +198,20 @@ func (sc *StatsCalculator) IsDateOverdue(todos []domain.Todo, todoDate time.Time
 	return false
 }
 
+// TodayByHour buckets todos with a due time by hour-of-day (0-23), for the
+// agenda view's timeline. Todos without a due time (Todo.HasDueTime) are
+// not counted; they're shown separately as an "all day" group.
+func (sc *StatsCalculator) TodayByHour(todos []domain.Todo) map[int]int {
+	byHour := make(map[int]int)
+	for _, t := range todos {
+		if !t.HasDueTime() {
+			continue
+		}
+		byHour[t.DueAt.Hour()]++
+	}
+	return byHour
+}
+
 // AreAllComplete checks if all todos for a date are completed
 func (sc *StatsCalculator) AreAllComplete(todos []domain.Todo) bool {
 	if len(todos) == 0 {
@@ -1,56 +1,705 @@
 package service
 
 import (
+	"io"
+	"sort"
 	"strings"
 	"time"
 
 	"ctchen222/chronoflow/internal/domain"
 	"ctchen222/chronoflow/internal/repository"
+	"ctchen222/chronoflow/internal/search"
 )
 
 // TodoService provides business logic operations for todos
 type TodoService struct {
-	repo     repository.TodoRepository
-	timeProv TimeProvider
+	repo            repository.TodoRepository
+	timeProv        TimeProvider
+	configRepo      repository.ConfigRepository
+	config          domain.Config
+	searchIndex     *search.Index
+	searchIndexPath string
 }
 
-// NewTodoService creates a new TodoService
-func NewTodoService(repo repository.TodoRepository, timeProv TimeProvider) *TodoService {
+// NewTodoService creates a new TodoService. config is the already-loaded
+// configuration (see ConfigRepository.Load); configRepo is kept so that
+// later changes, such as SortBy, can be persisted back out. searchIndexPath
+// is where the full-text search index (see internal/search) is persisted;
+// if no usable snapshot is found there, the index is rebuilt from repo.
+func NewTodoService(repo repository.TodoRepository, timeProv TimeProvider, configRepo repository.ConfigRepository, config domain.Config, searchIndexPath string) *TodoService {
+	idx := search.NewIndex()
+	if ok, err := idx.Load(searchIndexPath); err != nil || !ok {
+		idx.Rebuild(repo.GetAll())
+	}
 	return &TodoService{
-		repo:     repo,
-		timeProv: timeProv,
+		repo:            repo,
+		timeProv:        timeProv,
+		configRepo:      configRepo,
+		config:          config,
+		searchIndex:     idx,
+		searchIndexPath: searchIndexPath,
 	}
 }
 
-// GetTodosForDate returns todos for a specific date with overdue status calculated
+// dateKeyLayout is the date-key format used by the repository layer.
+const dateKeyLayout = "2006-01-02"
+
+// GetTodosForDate returns todos for a specific date with overdue status
+// calculated, merging in virtual instances of recurring todos whose
+// schedule lands on date.
 func (s *TodoService) GetTodosForDate(date time.Time) []TodoWithStatus {
-	todos := s.repo.GetByDate(date)
 	today := s.timeProv.Today()
+	dateKey := date.Format(dateKeyLayout)
 
-	result := make([]TodoWithStatus, len(todos))
-	for i, td := range todos {
-		result[i] = TodoWithStatus{
-			Todo:      td,
-			IsOverdue: td.IsOverdue(date, today),
+	stored := s.repo.GetByDate(date)
+	order, depths := sortedTodoOrder(stored, s.SortModeFor(date))
+	result := make([]TodoWithStatus, 0, len(stored))
+	excepted := make(map[string]bool) // UIDs skipped via EXDATE, so their subtasks skip too
+	for _, idx := range order {
+		td := stored[idx]
+		if td.ParentUID != "" && excepted[td.ParentUID] {
+			excepted[td.UID] = true
+			continue
+		}
+		if td.Recurrence != nil && td.Recurrence.IsException(date) {
+			excepted[td.UID] = true
+			continue
+		}
+		inst := td
+		if td.Recurrence != nil {
+			inst.Complete = hasOccurrence(td.CompletedOccurrences, dateKey)
+			inst = inst.WithOverride(dateKey)
+			if td.HasDueTime() {
+				// Reanchor the series' due time-of-day onto this occurrence's
+				// date, so DueReminders/ReminderAt fire per-occurrence rather
+				// than only on the series' original date.
+				due := td.DueAt
+				inst.DueAt = time.Date(date.Year(), date.Month(), date.Day(), due.Hour(), due.Minute(), 0, 0, due.Location())
+			}
+		}
+		result = append(result, TodoWithStatus{
+			Todo:           inst,
+			IsOverdue:      inst.IsOverdue(date, today),
+			ParentDate:     date,
+			ParentIndex:    idx,
+			OccurrenceDate: date,
+			Depth:          depths[idx],
+		})
+	}
+
+	for key, todos := range s.repo.GetAll() {
+		if key == dateKey {
+			continue
+		}
+		dtstart, err := time.Parse(dateKeyLayout, key)
+		if err != nil {
+			continue
+		}
+		for idx, td := range todos {
+			if td.Recurrence == nil || !td.Recurrence.Matches(dtstart, date) {
+				continue
+			}
+			inst := td
+			inst.Complete = hasOccurrence(td.CompletedOccurrences, dateKey)
+			inst = inst.WithOverride(dateKey)
+			result = append(result, TodoWithStatus{
+				Todo:           inst,
+				IsOverdue:      inst.IsOverdue(date, today),
+				IsInstance:     true,
+				ParentDate:     dtstart,
+				ParentIndex:    idx,
+				OccurrenceDate: date,
+			})
+		}
+	}
+	return result
+}
+
+// ExpandOccurrences materializes virtual instances of every recurring todo
+// whose schedule lands between start and end (inclusive), without
+// duplicating anything in storage.
+func (s *TodoService) ExpandOccurrences(start, end time.Time) []TodoWithStatus {
+	today := s.timeProv.Today()
+	var result []TodoWithStatus
+
+	for key, todos := range s.repo.GetAll() {
+		dtstart, err := time.ParseInLocation(dateKeyLayout, key, time.Local)
+		if err != nil {
+			continue
+		}
+		for idx, td := range todos {
+			if td.Recurrence == nil {
+				continue
+			}
+			for _, occDate := range td.Recurrence.Occurrences(dtstart, start, end) {
+				inst := td
+				occKey := occDate.Format(dateKeyLayout)
+				inst.Complete = hasOccurrence(td.CompletedOccurrences, occKey)
+				inst = inst.WithOverride(occKey)
+				result = append(result, TodoWithStatus{
+					Todo:           inst,
+					IsOverdue:      inst.IsOverdue(occDate, today),
+					IsInstance:     !occDate.Equal(dtstart),
+					ParentDate:     dtstart,
+					ParentIndex:    idx,
+					OccurrenceDate: occDate,
+				})
+			}
+		}
+	}
+	return result
+}
+
+// TodosForCalendar returns all todos grouped by date like GetAllTodos, but
+// with recurring todos materialized on every occurrence date and multi-day
+// todos materialized on every date they span, within [start, end), so
+// calendar views (month/week grids) can mark every day they touch instead
+// of only the date they're stored under.
+func (s *TodoService) TodosForCalendar(start, end time.Time) map[string][]domain.Todo {
+	result := make(map[string][]domain.Todo, len(s.repo.GetAll()))
+	for key, todos := range s.repo.GetAll() {
+		dtstart, err := time.Parse(dateKeyLayout, key)
+		if err != nil {
+			continue
+		}
+		plain := make([]domain.Todo, 0, len(todos))
+		for _, td := range todos {
+			if td.Recurrence != nil {
+				continue // materialized below via ExpandOccurrences
+			}
+			plain = append(plain, td)
+			if !td.HasEndDate() {
+				continue
+			}
+			for d := dtstart.AddDate(0, 0, 1); !d.After(td.EndDate); d = d.AddDate(0, 0, 1) {
+				if d.Before(start) || !d.Before(end) {
+					continue
+				}
+				dKey := d.Format(dateKeyLayout)
+				result[dKey] = append(result[dKey], td)
+			}
+		}
+		if len(plain) > 0 {
+			result[key] = append(result[key], plain...)
 		}
 	}
+
+	for _, occ := range s.ExpandOccurrences(start, end.AddDate(0, 0, -1)) {
+		occKey := occ.OccurrenceDate.Format(dateKeyLayout)
+		result[occKey] = append(result[occKey], occ.Todo)
+	}
 	return result
 }
 
+// ToggleOccurrence toggles completion of a single occurrence of a recurring
+// todo (identified by where its master is stored) without affecting any
+// other occurrence in the series.
+func (s *TodoService) ToggleOccurrence(parentDate time.Time, parentIndex int, occurrenceDate time.Time) error {
+	todos := s.repo.GetByDate(parentDate)
+	if parentIndex < 0 || parentIndex >= len(todos) {
+		return nil
+	}
+	td := todos[parentIndex]
+	occKey := occurrenceDate.Format(dateKeyLayout)
+	if i := indexOfOccurrence(td.CompletedOccurrences, occKey); i >= 0 {
+		td.CompletedOccurrences = append(td.CompletedOccurrences[:i], td.CompletedOccurrences[i+1:]...)
+	} else {
+		td.CompletedOccurrences = append(td.CompletedOccurrences, occKey)
+	}
+	td.LastModified = s.timeProv.Now()
+	return s.repo.Save(parentDate, parentIndex, td)
+}
+
+// UpdateOccurrence edits a single occurrence of a recurring todo ("this
+// event" scope) without touching the master or any other occurrence,
+// recording the edit in the master's Overrides map.
+func (s *TodoService) UpdateOccurrence(parentDate time.Time, parentIndex int, occurrenceDate time.Time, title, desc string, priority domain.Priority) error {
+	todos := s.repo.GetByDate(parentDate)
+	if parentIndex < 0 || parentIndex >= len(todos) {
+		return nil
+	}
+	td := todos[parentIndex]
+	if td.Overrides == nil {
+		td.Overrides = make(map[string]domain.Override)
+	}
+	td.Overrides[occurrenceDate.Format(dateKeyLayout)] = domain.Override{Title: title, Desc: desc, Priority: priority}
+	td.LastModified = s.timeProv.Now()
+	return s.repo.Save(parentDate, parentIndex, td)
+}
+
+// SplitSeries edits a recurring todo with the "this and future events"
+// scope: the existing series stops the day before occurrenceDate, and a
+// new series starting on occurrenceDate (with the edited fields and the
+// same recurrence pattern but no prior COUNT/UNTIL constraint) takes over
+// from there. Occurrences before occurrenceDate keep their completion
+// state and overrides; the new series starts fresh.
+func (s *TodoService) SplitSeries(parentDate time.Time, parentIndex int, occurrenceDate time.Time, title, desc string, priority domain.Priority) error {
+	todos := s.repo.GetByDate(parentDate)
+	if parentIndex < 0 || parentIndex >= len(todos) {
+		return nil
+	}
+	td := todos[parentIndex]
+	if td.Recurrence == nil {
+		return s.Update(parentDate, parentIndex, title, desc, priority, nil, td.DueAt, td.AlarmOffset)
+	}
+
+	now := s.timeProv.Now()
+
+	oldRecurrence := *td.Recurrence
+	oldRecurrence.Until = occurrenceDate.AddDate(0, 0, -1)
+	oldRecurrence.Count = 0
+	td.Recurrence = &oldRecurrence
+	td.LastModified = now
+	if err := s.repo.Save(parentDate, parentIndex, td); err != nil {
+		return err
+	}
+
+	newRecurrence := *td.Recurrence
+	newRecurrence.Until = time.Time{}
+	newRecurrence.Count = 0
+	newRecurrence.ExceptionDates = nil
+	newTodo := domain.Todo{
+		UID:          domain.NewUID(),
+		Title:        title,
+		Desc:         desc,
+		Priority:     priority,
+		LastModified: now,
+		Recurrence:   &newRecurrence,
+	}
+	if err := s.repo.Add(occurrenceDate, newTodo); err != nil {
+		return err
+	}
+	s.searchIndex.Upsert(occurrenceDate.Format(dateKeyLayout), newTodo)
+	return nil
+}
+
+// DeleteOccurrence removes a single occurrence of a recurring todo ("this
+// event" scope) by recording it as an EXDATE on the master, leaving other
+// occurrences and the master's own fields untouched.
+func (s *TodoService) DeleteOccurrence(parentDate time.Time, parentIndex int, occurrenceDate time.Time) error {
+	todos := s.repo.GetByDate(parentDate)
+	if parentIndex < 0 || parentIndex >= len(todos) {
+		return nil
+	}
+	td := todos[parentIndex]
+	if td.Recurrence == nil {
+		return s.Delete(parentDate, parentIndex)
+	}
+	recurrence := *td.Recurrence
+	recurrence.ExceptionDates = append(recurrence.ExceptionDates, occurrenceDate)
+	td.Recurrence = &recurrence
+	td.LastModified = s.timeProv.Now()
+	return s.repo.Save(parentDate, parentIndex, td)
+}
+
+// TruncateSeries deletes a recurring todo's occurrences from occurrenceDate
+// onward ("this and future events" scope) by moving the series' UNTIL back
+// to the day before occurrenceDate; earlier occurrences are unaffected. If
+// occurrenceDate doesn't come after the master's own date, the whole series
+// is deleted instead since there'd be nothing left before the cut.
+func (s *TodoService) TruncateSeries(parentDate time.Time, parentIndex int, occurrenceDate time.Time) error {
+	todos := s.repo.GetByDate(parentDate)
+	if parentIndex < 0 || parentIndex >= len(todos) {
+		return nil
+	}
+	td := todos[parentIndex]
+	if td.Recurrence == nil || !occurrenceDate.After(parentDate) {
+		return s.Delete(parentDate, parentIndex)
+	}
+	recurrence := *td.Recurrence
+	recurrence.Until = occurrenceDate.AddDate(0, 0, -1)
+	recurrence.Count = 0
+	td.Recurrence = &recurrence
+	td.LastModified = s.timeProv.Now()
+	return s.repo.Save(parentDate, parentIndex, td)
+}
+
+func hasOccurrence(dateKeys []string, key string) bool {
+	return indexOfOccurrence(dateKeys, key) >= 0
+}
+
+func indexOfOccurrence(dateKeys []string, key string) int {
+	for i, k := range dateKeys {
+		if k == key {
+			return i
+		}
+	}
+	return -1
+}
+
 // TodoWithStatus wraps a Todo with calculated display status
 type TodoWithStatus struct {
 	domain.Todo
 	IsOverdue bool
+
+	// IsInstance is true when this entry is a virtual occurrence of a
+	// recurring todo stored on a different date, rather than a todo
+	// literally stored at the requested date. ParentDate/ParentIndex
+	// locate the stored master so it can be addressed (toggled, edited).
+	IsInstance  bool
+	ParentDate  time.Time
+	ParentIndex int
+
+	// OccurrenceDate is the calendar date this entry is displayed on: the
+	// requested date for a plain todo or recurring instance alike, as
+	// opposed to ParentDate which always names where the master is stored.
+	OccurrenceDate time.Time
+
+	// Depth is the todo's nesting level in the subtask tree (0 = top-level).
+	Depth int
+}
+
+// buildTodoOrder walks todos' ParentUID links into a depth-first tree
+// order (each parent immediately followed by its descendants), returning
+// the original indices in that order plus each index's nesting depth.
+// Todos whose ParentUID doesn't match any sibling are treated as
+// top-level so a dangling reference never hides a todo from the list.
+func buildTodoOrder(todos []domain.Todo) (order []int, depth []int) {
+	present := make(map[string]bool, len(todos))
+	for _, td := range todos {
+		if td.UID != "" {
+			present[td.UID] = true
+		}
+	}
+
+	childrenOf := make(map[string][]int)
+	var roots []int
+	for i, td := range todos {
+		if td.ParentUID != "" && present[td.ParentUID] {
+			childrenOf[td.ParentUID] = append(childrenOf[td.ParentUID], i)
+		} else {
+			roots = append(roots, i)
+		}
+	}
+
+	order = make([]int, 0, len(todos))
+	depth = make([]int, len(todos))
+
+	var walk func(i, d int)
+	walk = func(i, d int) {
+		order = append(order, i)
+		depth[i] = d
+		for _, c := range childrenOf[todos[i].UID] {
+			walk(c, d+1)
+		}
+	}
+	for _, r := range roots {
+		walk(r, 0)
+	}
+	return order, depth
+}
+
+// SortModeFor returns the sort mode configured for date, defaulting to
+// SortManual when none was set.
+func (s *TodoService) SortModeFor(date time.Time) SortMode {
+	mode, err := ParseSortMode(s.config.SortModes[date.Format(dateKeyLayout)])
+	if err != nil {
+		return SortManual
+	}
+	return mode
+}
+
+// SortBy sets the sort mode used to order date's todos in GetTodosForDate,
+// persisting the choice so it's remembered across restarts.
+func (s *TodoService) SortBy(date time.Time, mode SortMode) error {
+	if s.config.SortModes == nil {
+		s.config.SortModes = make(map[string]string)
+	}
+	s.config.SortModes[date.Format(dateKeyLayout)] = mode.String()
+	if s.configRepo == nil {
+		return nil
+	}
+	return s.configRepo.Save(s.config)
+}
+
+// sortedTodoOrder is buildTodoOrder's tree walk with each sibling group
+// (todos sharing a parent) additionally ordered by mode before descending
+// into it. SortManual leaves storage order untouched; DemoteTodo and
+// PromoteTodo intentionally keep using buildTodoOrder directly, since they
+// reason about structure rather than display order.
+func sortedTodoOrder(todos []domain.Todo, mode SortMode) (order []int, depth []int) {
+	if mode == SortManual {
+		return buildTodoOrder(todos)
+	}
+
+	present := make(map[string]bool, len(todos))
+	for _, td := range todos {
+		if td.UID != "" {
+			present[td.UID] = true
+		}
+	}
+
+	childrenOf := make(map[string][]int)
+	var roots []int
+	for i, td := range todos {
+		if td.ParentUID != "" && present[td.ParentUID] {
+			childrenOf[td.ParentUID] = append(childrenOf[td.ParentUID], i)
+		} else {
+			roots = append(roots, i)
+		}
+	}
+
+	less := func(group []int) func(a, b int) bool {
+		return func(a, b int) bool {
+			return lessForSort(todos[group[a]], todos[group[b]], mode)
+		}
+	}
+	sort.SliceStable(roots, less(roots))
+	for uid, group := range childrenOf {
+		sort.SliceStable(group, less(group))
+		childrenOf[uid] = group
+	}
+
+	order = make([]int, 0, len(todos))
+	depth = make([]int, len(todos))
+
+	var walk func(i, d int)
+	walk = func(i, d int) {
+		order = append(order, i)
+		depth[i] = d
+		for _, c := range childrenOf[todos[i].UID] {
+			walk(c, d+1)
+		}
+	}
+	for _, r := range roots {
+		walk(r, 0)
+	}
+	return order, depth
 }
 
-// ToggleComplete toggles the completion status of a todo
+// lessForSort orders a and b according to mode. Ties (and todos missing
+// the sorted-on field) fall back to title, for a stable, predictable order.
+func lessForSort(a, b domain.Todo, mode SortMode) bool {
+	switch mode {
+	case SortPriority:
+		if a.Priority != b.Priority {
+			return a.Priority > b.Priority // High before Low
+		}
+	case SortDueTime:
+		aHas, bHas := a.HasDueTime(), b.HasDueTime()
+		if aHas != bHas {
+			return aHas // todos with a due time sort before those without
+		}
+		if aHas && !a.DueAt.Equal(b.DueAt) {
+			return a.DueAt.Before(b.DueAt)
+		}
+	case SortTitle:
+		// handled by the fallback below
+	}
+	return strings.ToLower(a.Title) < strings.ToLower(b.Title)
+}
+
+// AddSubtask creates a new todo parented under parentUID on the given date.
+func (s *TodoService) AddSubtask(date time.Time, parentUID, title, desc string, priority domain.Priority) error {
+	if title == "" {
+		return nil
+	}
+	todo := domain.Todo{
+		UID:          domain.NewUID(),
+		Title:        title,
+		Desc:         desc,
+		Priority:     priority,
+		LastModified: s.timeProv.Now(),
+		ParentUID:    parentUID,
+	}
+	if err := s.repo.Add(date, todo); err != nil {
+		return err
+	}
+	s.searchIndex.Upsert(date.Format(dateKeyLayout), todo)
+	return nil
+}
+
+// ListSubtasks returns the direct children of parentUID on the given date.
+func (s *TodoService) ListSubtasks(date time.Time, parentUID string) []domain.Todo {
+	var children []domain.Todo
+	for _, td := range s.repo.GetByDate(date) {
+		if td.ParentUID == parentUID {
+			children = append(children, td)
+		}
+	}
+	return children
+}
+
+// ProgressOf returns the completed/total count across every descendant
+// (not just direct children) of parentUID on the given date.
+func (s *TodoService) ProgressOf(date time.Time, parentUID string) (completed, total int) {
+	childrenOf := make(map[string][]domain.Todo)
+	for _, td := range s.repo.GetByDate(date) {
+		if td.ParentUID != "" {
+			childrenOf[td.ParentUID] = append(childrenOf[td.ParentUID], td)
+		}
+	}
+	var walk func(uid string)
+	walk = func(uid string) {
+		for _, c := range childrenOf[uid] {
+			total++
+			if c.Complete {
+				completed++
+			}
+			walk(c.UID)
+		}
+	}
+	walk(parentUID)
+	return completed, total
+}
+
+// SetParent reparents the todo at index under parentUID (empty string
+// promotes it to top-level), used to implement indent/outdent keybindings.
+func (s *TodoService) SetParent(date time.Time, index int, parentUID string) error {
+	todos := s.repo.GetByDate(date)
+	if index < 0 || index >= len(todos) {
+		return nil
+	}
+	todos[index].ParentUID = parentUID
+	todos[index].LastModified = s.timeProv.Now()
+	return s.repo.Save(date, index, todos[index])
+}
+
+// PromoteTodo moves the todo at index up one indentation level, making it
+// a sibling of its current parent. No-op if it's already top-level.
+func (s *TodoService) PromoteTodo(date time.Time, index int) error {
+	todos := s.repo.GetByDate(date)
+	if index < 0 || index >= len(todos) || todos[index].ParentUID == "" {
+		return nil
+	}
+	var grandparentUID string
+	for _, td := range todos {
+		if td.UID == todos[index].ParentUID {
+			grandparentUID = td.ParentUID
+			break
+		}
+	}
+	return s.SetParent(date, index, grandparentUID)
+}
+
+// DemoteTodo makes the todo at index a subtask of the nearest preceding
+// sibling in tree order (same depth, same parent). No-op if there's no
+// such sibling (e.g. it's already the first item at its level).
+func (s *TodoService) DemoteTodo(date time.Time, index int) error {
+	todos := s.repo.GetByDate(date)
+	if index < 0 || index >= len(todos) {
+		return nil
+	}
+	order, depth := buildTodoOrder(todos)
+
+	pos := -1
+	for i, idx := range order {
+		if idx == index {
+			pos = i
+			break
+		}
+	}
+	if pos <= 0 {
+		return nil
+	}
+
+	target := todos[index]
+	for i := pos - 1; i >= 0; i-- {
+		candIdx := order[i]
+		if depth[candIdx] < depth[index] {
+			return nil // hit the parent level without finding a sibling
+		}
+		if depth[candIdx] == depth[index] && todos[candIdx].ParentUID == target.ParentUID {
+			return s.SetParent(date, index, todos[candIdx].UID)
+		}
+	}
+	return nil
+}
+
+// ToggleComplete toggles the completion status of a todo. If the todo is a
+// subtask, this also syncs its ancestors: completing the last incomplete
+// sibling auto-completes the parent, and unchecking a child uncompletes
+// every ancestor up the chain. If the todo has subtasks of its own,
+// completing it also completes every descendant (see syncDescendants);
+// un-completing it leaves descendants untouched.
 func (s *TodoService) ToggleComplete(date time.Time, index int) error {
 	todos := s.repo.GetByDate(date)
 	if index < 0 || index >= len(todos) {
 		return nil
 	}
 	todos[index].Complete = !todos[index].Complete
-	return s.repo.Save(date, index, todos[index])
+	todos[index].LastModified = s.timeProv.Now()
+	if err := s.repo.Save(date, index, todos[index]); err != nil {
+		return err
+	}
+	s.searchIndex.Upsert(date.Format(dateKeyLayout), todos[index])
+	if todos[index].Complete {
+		if err := s.syncDescendants(date, todos[index].UID); err != nil {
+			return err
+		}
+	}
+	return s.syncAncestors(date, todos[index])
+}
+
+// syncDescendants completes every descendant of parentUID on date, mirroring
+// the common "check off a parent to finish the whole list" gesture. It only
+// runs when the parent is being marked complete; un-completing a parent
+// leaves its children as they were.
+func (s *TodoService) syncDescendants(date time.Time, parentUID string) error {
+	todos := s.repo.GetByDate(date)
+	for i, td := range todos {
+		if td.ParentUID != parentUID || td.Complete {
+			continue
+		}
+		td.Complete = true
+		td.LastModified = s.timeProv.Now()
+		if err := s.repo.Save(date, i, td); err != nil {
+			return err
+		}
+		s.searchIndex.Upsert(date.Format(dateKeyLayout), td)
+		if err := s.syncDescendants(date, td.UID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// syncAncestors propagates a child's completion state up its parent chain.
+func (s *TodoService) syncAncestors(date time.Time, child domain.Todo) error {
+	if child.ParentUID == "" {
+		return nil
+	}
+	todos := s.repo.GetByDate(date)
+	parentIdx := -1
+	for i, td := range todos {
+		if td.UID == child.ParentUID {
+			parentIdx = i
+			break
+		}
+	}
+	if parentIdx == -1 {
+		return nil
+	}
+	parent := todos[parentIdx]
+
+	if !child.Complete {
+		if parent.Complete {
+			parent.Complete = false
+			parent.LastModified = s.timeProv.Now()
+			if err := s.repo.Save(date, parentIdx, parent); err != nil {
+				return err
+			}
+			s.searchIndex.Upsert(date.Format(dateKeyLayout), parent)
+		}
+		return s.syncAncestors(date, parent)
+	}
+
+	allComplete := true
+	for _, td := range todos {
+		if td.ParentUID == parent.UID && !td.Complete {
+			allComplete = false
+			break
+		}
+	}
+	if allComplete && !parent.Complete {
+		parent.Complete = true
+		parent.LastModified = s.timeProv.Now()
+		if err := s.repo.Save(date, parentIdx, parent); err != nil {
+			return err
+		}
+		s.searchIndex.Upsert(date.Format(dateKeyLayout), parent)
+	}
+	return s.syncAncestors(date, parent)
 }
 
 // SetPriority sets the priority of a todo
@@ -60,37 +709,106 @@ func (s *TodoService) SetPriority(date time.Time, index int, priority domain.Pri
 		return nil
 	}
 	todos[index].Priority = priority
-	return s.repo.Save(date, index, todos[index])
+	todos[index].LastModified = s.timeProv.Now()
+	if err := s.repo.Save(date, index, todos[index]); err != nil {
+		return err
+	}
+	s.searchIndex.Upsert(date.Format(dateKeyLayout), todos[index])
+	return nil
 }
 
-// Add creates a new todo for a date
-func (s *TodoService) Add(date time.Time, title, desc string, priority domain.Priority) error {
+// Add creates a new todo for a date. recurrence is nil for a one-off todo.
+// dueAt is the zero time for no specific time of day, in which case
+// alarmOffset is ignored.
+func (s *TodoService) Add(date time.Time, title, desc string, priority domain.Priority, recurrence *domain.Recurrence, dueAt time.Time, alarmOffset time.Duration) error {
 	if title == "" {
 		return nil
 	}
+	if dueAt.IsZero() {
+		alarmOffset = 0
+	}
 	todo := domain.Todo{
-		Title:    title,
-		Desc:     desc,
-		Priority: priority,
+		UID:          domain.NewUID(),
+		Title:        title,
+		Desc:         desc,
+		Priority:     priority,
+		LastModified: s.timeProv.Now(),
+		Recurrence:   recurrence,
+		DueAt:        dueAt,
+		AlarmOffset:  alarmOffset,
+	}
+	if err := s.repo.Add(date, todo); err != nil {
+		return err
 	}
-	return s.repo.Add(date, todo)
+	s.searchIndex.Upsert(date.Format(dateKeyLayout), todo)
+	return nil
 }
 
-// Update updates an existing todo
-func (s *TodoService) Update(date time.Time, index int, title, desc string, priority domain.Priority) error {
+// Update updates an existing todo, including its recurrence rule (nil
+// clears it) and due time (a zero dueAt clears it, taking alarmOffset with
+// it). This always applies to the whole series ("all events" scope); see
+// UpdateOccurrence and SplitSeries for the other two edit scopes.
+func (s *TodoService) Update(date time.Time, index int, title, desc string, priority domain.Priority, recurrence *domain.Recurrence, dueAt time.Time, alarmOffset time.Duration) error {
 	todos := s.repo.GetByDate(date)
 	if index < 0 || index >= len(todos) {
 		return nil
 	}
+	if dueAt.IsZero() {
+		alarmOffset = 0
+	}
 	todos[index].Title = title
 	todos[index].Desc = desc
 	todos[index].Priority = priority
-	return s.repo.Save(date, index, todos[index])
+	todos[index].Recurrence = recurrence
+	todos[index].DueAt = dueAt
+	todos[index].AlarmOffset = alarmOffset
+	todos[index].LastModified = s.timeProv.Now()
+	if err := s.repo.Save(date, index, todos[index]); err != nil {
+		return err
+	}
+	s.searchIndex.Upsert(date.Format(dateKeyLayout), todos[index])
+	return nil
+}
+
+// DueReminders returns every incomplete todo on date whose reminder time
+// (Todo.ReminderAt) has arrived by now but not yet been reported, i.e. its
+// "UID@date" key is absent from alreadyFired; that key (rather than bare
+// UID) is what's marked fired, so a recurring todo's reminder fires once per
+// occurrence instead of only once ever. Callers own alreadyFired's lifetime
+// (main.go keeps it as transient, in-memory, per-session state); DueReminders
+// updates it in place for every todo it returns.
+func (s *TodoService) DueReminders(date, now time.Time, alreadyFired map[string]bool) []domain.Todo {
+	var due []domain.Todo
+	dateKey := date.Format(dateKeyLayout)
+	for _, td := range s.GetTodosForDate(date) {
+		key := td.UID + "@" + dateKey
+		if alreadyFired[key] {
+			continue
+		}
+		at, ok := td.ReminderAt()
+		if !ok || now.Before(at) {
+			continue
+		}
+		alreadyFired[key] = true
+		due = append(due, td.Todo)
+	}
+	return due
 }
 
 // Delete removes a todo
 func (s *TodoService) Delete(date time.Time, index int) error {
-	return s.repo.Delete(date, index)
+	todos := s.repo.GetByDate(date)
+	var uid string
+	if index >= 0 && index < len(todos) {
+		uid = todos[index].UID
+	}
+	if err := s.repo.Delete(date, index); err != nil {
+		return err
+	}
+	if uid != "" {
+		s.searchIndex.Delete(uid)
+	}
+	return nil
 }
 
 // MoveUp moves a todo up in the list (swap with previous)
@@ -110,44 +828,123 @@ func (s *TodoService) MoveDown(date time.Time, index int) error {
 	return s.repo.Reorder(date, index, index+1)
 }
 
-// SearchResult represents a search result
+// SearchResult represents a single ranked match from Search.
 type SearchResult struct {
-	DateKey string
-	Index   int
-	Todo    domain.Todo
+	DateKey        string
+	Index          int
+	Todo           domain.Todo
+	Score          float64
+	MatchPositions []int
 }
 
-// Search searches all todos for the given query
-func (s *TodoService) Search(query string) []SearchResult {
-	if query == "" {
+// Search finds todos matching query against the full-text index (see
+// internal/search for the supported syntax: bare terms, prefix*, "exact
+// phrases", and title:/date:/is:/p: filters). Free text is fuzzy-matched
+// fzf-style against title (full weight) and description (weighted lower);
+// caseSensitive controls whether that fuzzy match is case-sensitive.
+// Results are ranked by match score and, for ties, by date proximity to
+// today.
+func (s *TodoService) Search(query string, caseSensitive bool) []SearchResult {
+	if strings.TrimSpace(query) == "" {
 		return nil
 	}
 
-	query = strings.ToLower(query)
-	allTodos := s.repo.GetAll()
+	hits := s.searchIndex.Search(search.ParseQuery(query), s.timeProv.Today(), caseSensitive)
 
-	var results []SearchResult
-	for dateKey, items := range allTodos {
-		for idx, td := range items {
-			if strings.Contains(strings.ToLower(td.Title), query) ||
-				strings.Contains(strings.ToLower(td.Desc), query) {
-				results = append(results, SearchResult{
-					DateKey: dateKey,
-					Index:   idx,
-					Todo:    td,
-				})
-			}
+	results := make([]SearchResult, 0, len(hits))
+	for _, hit := range hits {
+		todo, index, ok := s.findByUID(hit.DateKey, hit.UID)
+		if !ok {
+			continue
 		}
+		results = append(results, SearchResult{
+			DateKey:        hit.DateKey,
+			Index:          index,
+			Todo:           todo,
+			Score:          hit.Score,
+			MatchPositions: hit.MatchPositions,
+		})
 	}
 	return results
 }
 
+// TodosFromResults adapts Search's results into TodoWithStatus entries
+// suitable for TodoPresenter.ToListItems, for callers (saved views) that
+// render a query's results as the todo panel's contents instead of a
+// single date's todos. Entries are flattened (no subtask nesting or
+// recurrence-instance bookkeeping) since a view spans many dates at once.
+func (s *TodoService) TodosFromResults(results []SearchResult) []TodoWithStatus {
+	today := s.timeProv.Today()
+	out := make([]TodoWithStatus, 0, len(results))
+	for _, r := range results {
+		date, err := time.Parse(dateKeyLayout, r.DateKey)
+		if err != nil {
+			continue
+		}
+		out = append(out, TodoWithStatus{
+			Todo:           r.Todo,
+			IsOverdue:      r.Todo.IsOverdue(date, today),
+			OccurrenceDate: date,
+		})
+	}
+	return out
+}
+
+// findByUID locates a todo by UID within a date's list, returning its
+// current index. Search results are keyed by UID internally (stable across
+// edits and reorders) but every other SearchResult consumer addresses
+// todos by (date, index), so this bridges the two.
+func (s *TodoService) findByUID(dateKey, uid string) (domain.Todo, int, bool) {
+	date, err := time.Parse(dateKeyLayout, dateKey)
+	if err != nil {
+		return domain.Todo{}, 0, false
+	}
+	for i, td := range s.repo.GetByDate(date) {
+		if td.UID == uid {
+			return td, i, true
+		}
+	}
+	return domain.Todo{}, 0, false
+}
+
 // GetAllTodos returns all todos (for stats calculation)
 func (s *TodoService) GetAllTodos() map[string][]domain.Todo {
 	return s.repo.GetAll()
 }
 
-// Persist saves all todos to persistent storage
+// Persist saves all todos, plus the search index, to persistent storage.
 func (s *TodoService) Persist() error {
-	return s.repo.Persist()
+	if err := s.repo.Persist(); err != nil {
+		return err
+	}
+	return s.searchIndex.Save(s.searchIndexPath)
+}
+
+// Import reads a VCALENDAR document from r and merges each VTODO it
+// contains into the store (a todo with the same UID is updated rather than
+// duplicated), reindexing search afterward so the imported todos are
+// searchable immediately.
+func (s *TodoService) Import(r io.Reader) error {
+	if err := s.repo.Import(r); err != nil {
+		return err
+	}
+	for dateKey, todos := range s.repo.GetAll() {
+		for _, td := range todos {
+			s.searchIndex.Upsert(dateKey, td)
+		}
+	}
+	return nil
+}
+
+// Export writes every todo as a VTODO inside a single VCALENDAR document,
+// for interchange with any RFC 5545-compatible tool.
+func (s *TodoService) Export(w io.Writer) error {
+	return s.repo.Export(w)
+}
+
+// ExportRange is like Export but writes only todos scheduled in [from, to]
+// (inclusive), for exporting just the range currently visible on the
+// calendar rather than the whole store.
+func (s *TodoService) ExportRange(w io.Writer, from, to time.Time) error {
+	return s.repo.ExportRange(w, from, to)
 }
@@ -0,0 +1,212 @@
+package service
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"ctchen222/chronoflow/internal/domain"
+	"ctchen222/chronoflow/internal/repository"
+)
+
+func newTestService(t *testing.T, now time.Time) (*TodoService, *MockTimeProvider) {
+	t.Helper()
+	dir := t.TempDir()
+	repo := repository.NewJSONTodoRepository(filepath.Join(dir, "todos.json"))
+	configRepo := repository.NewJSONConfigRepository(filepath.Join(dir, "config.json"))
+	tp := NewMockTimeProvider(now)
+	svc := NewTodoService(repo, tp, configRepo, domain.DefaultConfig(), filepath.Join(dir, "search.idx"))
+	return svc, tp
+}
+
+func TestTodoServiceDueRemindersFiresOncePerOccurrence(t *testing.T) {
+	date := time.Date(2026, 7, 26, 0, 0, 0, 0, time.Local)
+	svc, _ := newTestService(t, date)
+
+	due := date.Add(10 * time.Hour)
+	if err := svc.Add(date, "Take medicine", "", domain.PriorityNone, nil, due, 30*time.Minute); err != nil {
+		t.Fatalf("Add: %v", err)
+	}
+
+	fired := make(map[string]bool)
+	before := due.Add(-time.Hour)
+	if got := svc.DueReminders(date, before, fired); len(got) != 0 {
+		t.Fatalf("DueReminders before alarm time = %+v, want none", got)
+	}
+
+	at := due.Add(-20 * time.Minute)
+	got := svc.DueReminders(date, at, fired)
+	if len(got) != 1 || got[0].Title != "Take medicine" {
+		t.Fatalf("DueReminders at alarm time = %+v, want one match", got)
+	}
+
+	if got := svc.DueReminders(date, due, fired); len(got) != 0 {
+		t.Errorf("DueReminders re-fired an already-reported reminder: %+v", got)
+	}
+}
+
+func TestTodoServiceDueRemindersSkipsCompleted(t *testing.T) {
+	date := time.Date(2026, 7, 26, 0, 0, 0, 0, time.Local)
+	svc, _ := newTestService(t, date)
+
+	due := date.Add(9 * time.Hour)
+	if err := svc.Add(date, "Standup", "", domain.PriorityNone, nil, due, time.Hour); err != nil {
+		t.Fatalf("Add: %v", err)
+	}
+	if err := svc.ToggleComplete(date, 0); err != nil {
+		t.Fatalf("ToggleComplete: %v", err)
+	}
+
+	fired := make(map[string]bool)
+	if got := svc.DueReminders(date, due, fired); len(got) != 0 {
+		t.Errorf("DueReminders returned a completed todo: %+v", got)
+	}
+}
+
+// TestTodoServiceDueRemindersCrossesDateBoundary reproduces the scenario
+// from the chunk3-3 review: a todo due just after midnight with an
+// AlarmOffset long enough to push ReminderAt into the previous day. Scanning
+// only that previous day's reminders (the pre-fix behavior) must miss it;
+// scanning the due date itself must catch it.
+func TestTodoServiceDueRemindersCrossesDateBoundary(t *testing.T) {
+	today := time.Date(2026, 7, 26, 0, 0, 0, 0, time.Local)
+	tomorrow := today.AddDate(0, 0, 1)
+	svc, _ := newTestService(t, today)
+
+	// Due 1am tomorrow, remind 2 hours before -> ReminderAt is 11pm today.
+	due := tomorrow.Add(time.Hour)
+	if err := svc.Add(tomorrow, "Early deadline", "", domain.PriorityNone, nil, due, 2*time.Hour); err != nil {
+		t.Fatalf("Add: %v", err)
+	}
+
+	now := today.Add(23 * time.Hour) // 11pm today, the reminder instant
+	fired := make(map[string]bool)
+
+	if got := svc.DueReminders(today, now, fired); len(got) != 0 {
+		t.Errorf("DueReminders(today) found a todo stored on tomorrow: %+v", got)
+	}
+	got := svc.DueReminders(tomorrow, now, fired)
+	if len(got) != 1 || got[0].Title != "Early deadline" {
+		t.Fatalf("DueReminders(tomorrow) at the reminder instant = %+v, want one match", got)
+	}
+}
+
+func TestTodoServiceAddSubtaskProgressAndCascade(t *testing.T) {
+	date := time.Date(2026, 7, 26, 0, 0, 0, 0, time.Local)
+	svc, _ := newTestService(t, date)
+
+	if err := svc.Add(date, "Launch", "", domain.PriorityNone, nil, time.Time{}, 0); err != nil {
+		t.Fatalf("Add parent: %v", err)
+	}
+	parent := svc.GetTodosForDate(date)[0].Todo
+
+	if err := svc.AddSubtask(date, parent.UID, "Write docs", "", domain.PriorityNone); err != nil {
+		t.Fatalf("AddSubtask: %v", err)
+	}
+	if err := svc.AddSubtask(date, parent.UID, "Ship binary", "", domain.PriorityNone); err != nil {
+		t.Fatalf("AddSubtask: %v", err)
+	}
+
+	children := svc.ListSubtasks(date, parent.UID)
+	if len(children) != 2 {
+		t.Fatalf("ListSubtasks = %+v, want 2 children", children)
+	}
+
+	if completed, total := svc.ProgressOf(date, parent.UID); completed != 0 || total != 2 {
+		t.Fatalf("ProgressOf before completing anything = (%d, %d), want (0, 2)", completed, total)
+	}
+
+	todos := svc.GetTodosForDate(date)
+	var firstChildIdx, secondChildIdx, parentIdx int
+	for i, tw := range todos {
+		switch tw.Todo.UID {
+		case parent.UID:
+			parentIdx = i
+		case children[0].UID:
+			firstChildIdx = i
+		case children[1].UID:
+			secondChildIdx = i
+		}
+	}
+
+	// Completing the last incomplete child should auto-complete the parent.
+	if err := svc.ToggleComplete(date, firstChildIdx); err != nil {
+		t.Fatalf("ToggleComplete(first child): %v", err)
+	}
+	if completed, total := svc.ProgressOf(date, parent.UID); completed != 1 || total != 2 {
+		t.Fatalf("ProgressOf after one child done = (%d, %d), want (1, 2)", completed, total)
+	}
+	if svc.GetTodosForDate(date)[parentIdx].Todo.Complete {
+		t.Fatal("parent auto-completed with a child still incomplete")
+	}
+
+	if err := svc.ToggleComplete(date, secondChildIdx); err != nil {
+		t.Fatalf("ToggleComplete(second child): %v", err)
+	}
+	if !svc.GetTodosForDate(date)[parentIdx].Todo.Complete {
+		t.Fatal("parent did not auto-complete once every child was done")
+	}
+
+	// Un-completing a child should un-complete the parent again.
+	if err := svc.ToggleComplete(date, firstChildIdx); err != nil {
+		t.Fatalf("ToggleComplete(re-open first child): %v", err)
+	}
+	if svc.GetTodosForDate(date)[parentIdx].Todo.Complete {
+		t.Fatal("parent stayed complete after a child was re-opened")
+	}
+
+	// Completing the parent directly should cascade down to every child.
+	if err := svc.ToggleComplete(date, parentIdx); err != nil {
+		t.Fatalf("ToggleComplete(parent): %v", err)
+	}
+	for _, c := range svc.ListSubtasks(date, parent.UID) {
+		if !c.Complete {
+			t.Errorf("child %q not completed after completing parent", c.Title)
+		}
+	}
+}
+
+func TestTodoServiceExpandOccurrencesWeekly(t *testing.T) {
+	dtstart := time.Date(2026, 7, 6, 0, 0, 0, 0, time.Local) // a Monday
+	svc, _ := newTestService(t, dtstart)
+
+	recurrence, err := domain.ParseRRule("FREQ=WEEKLY;INTERVAL=1;BYDAY=MO,WE")
+	if err != nil {
+		t.Fatalf("ParseRRule: %v", err)
+	}
+	if err := svc.Add(dtstart, "Standup", "", domain.PriorityNone, &recurrence, time.Time{}, 0); err != nil {
+		t.Fatalf("Add: %v", err)
+	}
+
+	start := dtstart
+	end := dtstart.AddDate(0, 0, 14) // two weeks out
+
+	occurrences := svc.ExpandOccurrences(start, end)
+	if len(occurrences) != 5 {
+		t.Fatalf("ExpandOccurrences over two weeks = %d occurrences, want 5 (Mon/Wed x2 + closing Mon)", len(occurrences))
+	}
+
+	wantDays := map[time.Weekday]bool{time.Monday: true, time.Wednesday: true}
+	for _, occ := range occurrences {
+		if !wantDays[occ.OccurrenceDate.Weekday()] {
+			t.Errorf("occurrence on %s, want only Mon/Wed", occ.OccurrenceDate.Weekday())
+		}
+		if occ.Todo.Title != "Standup" {
+			t.Errorf("occurrence title = %q, want %q", occ.Todo.Title, "Standup")
+		}
+	}
+
+	// The dtstart instance itself is not a "virtual" IsInstance occurrence.
+	var sawDtstart bool
+	for _, occ := range occurrences {
+		if occ.OccurrenceDate.Equal(dtstart) {
+			sawDtstart = true
+			if occ.IsInstance {
+				t.Error("the dtstart occurrence should not be marked IsInstance")
+			}
+		}
+	}
+	if !sawDtstart {
+		t.Error("ExpandOccurrences did not include the dtstart occurrence itself")
+	}
+}
@@ -0,0 +1,83 @@
+package service
+
+import (
+	"sort"
+
+	"ctchen222/chronoflow/internal/domain"
+	"ctchen222/chronoflow/internal/repository"
+)
+
+// ViewService manages saved views: named, persisted search queries the
+// user can jump back to without retyping them, kept separate from
+// TodoService the same way HabitService is (a distinct concern bridged
+// together in main.go rather than threaded through TodoService itself).
+type ViewService struct {
+	repo repository.ViewRepository
+}
+
+// NewViewService creates a new ViewService.
+func NewViewService(repo repository.ViewRepository) *ViewService {
+	return &ViewService{repo: repo}
+}
+
+// GetAll returns every saved view, pinned views first, otherwise in the
+// order they were saved.
+func (s *ViewService) GetAll() []domain.SavedView {
+	views := s.repo.GetAll()
+	sort.SliceStable(views, func(i, j int) bool {
+		return views[i].Pinned && !views[j].Pinned
+	})
+	return views
+}
+
+// Save persists a new view named name with the given query.
+func (s *ViewService) Save(name, query string) (domain.SavedView, error) {
+	view := domain.NewSavedView(name, query)
+	if err := s.repo.Add(view); err != nil {
+		return domain.SavedView{}, err
+	}
+	return view, s.repo.Persist()
+}
+
+// Rename changes uid's display name.
+func (s *ViewService) Rename(uid, name string) error {
+	view, ok := s.find(uid)
+	if !ok {
+		return nil
+	}
+	view.Name = name
+	if err := s.repo.Update(view); err != nil {
+		return err
+	}
+	return s.repo.Persist()
+}
+
+// TogglePinned flips whether uid sorts to the front of GetAll.
+func (s *ViewService) TogglePinned(uid string) error {
+	view, ok := s.find(uid)
+	if !ok {
+		return nil
+	}
+	view.Pinned = !view.Pinned
+	if err := s.repo.Update(view); err != nil {
+		return err
+	}
+	return s.repo.Persist()
+}
+
+// Delete removes a saved view.
+func (s *ViewService) Delete(uid string) error {
+	if err := s.repo.Delete(uid); err != nil {
+		return err
+	}
+	return s.repo.Persist()
+}
+
+func (s *ViewService) find(uid string) (domain.SavedView, bool) {
+	for _, v := range s.repo.GetAll() {
+		if v.UID == uid {
+			return v, true
+		}
+	}
+	return domain.SavedView{}, false
+}
@@ -0,0 +1,468 @@
+// Package sync provides TodoRepository implementations that keep
+// chronoflow's todos in sync with an external calendar server: VTODO
+// mapping (domain.Todo.UID as the stable calendar-object identity,
+// Title/Desc/Complete/Priority/date mapped to SUMMARY/DESCRIPTION/
+// STATUS/PRIORITY/DUE via todoToVTODO and vtodoToTodo in ical.go),
+// keyring-backed credentials (internal/secrets, wired up in
+// cmd/chronoflow/main.go), and the first-run calendar picker
+// (ui.StateSelectingCalendar, populated via FindCalendars in main.go's
+// pickerRepo). See NewCalDAVTodoRepository, Pull and Push.
+//
+// VEVENTs are pulled as a read-only mirror (see Events) rather than pushed
+// from local todos: doing the latter would require a timed-todo concept
+// (start/end instants distinct from the DueAt deadline already on
+// domain.Todo) that doesn't exist on domain.Todo today, so there's nothing
+// to push as a VEVENT yet.
+package sync
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/emersion/go-ical"
+	"github.com/emersion/go-webdav"
+	"github.com/emersion/go-webdav/caldav"
+
+	"ctchen222/chronoflow/internal/domain"
+)
+
+// Options configures how CalDAVTodoRepository reaches the remote server.
+type Options struct {
+	URL      string
+	Username string
+	Password string
+	// Calendar is the server-assigned path of the calendar to sync
+	// against, as picked during the first-run calendar picker.
+	Calendar string
+}
+
+// pendingOp records a local mutation that couldn't be pushed to the server
+// (no network, server error) so it can be replayed once connectivity is
+// restored.
+type pendingOp struct {
+	dateKey string
+	uid     string
+	deleted bool
+}
+
+// CalDAVTodoRepository implements repository.TodoRepository by storing
+// todos as VTODO components on a remote CalDAV server, with an in-memory
+// cache so the TUI keeps working offline. Local edits made while offline
+// are recorded in a pending queue and flushed on the next successful Push.
+// The cache is additionally mirrored to cachePath on disk (the same JSON
+// shape JSONTodoRepository uses) so todos survive a restart that happens
+// before the server is next reachable.
+type CalDAVTodoRepository struct {
+	mu        sync.Mutex
+	client    *caldav.Client
+	calendar  string
+	cachePath string
+
+	todos   map[string][]domain.Todo // cache, same shape as JSONTodoRepository
+	pending []pendingOp
+
+	// etags records the last-seen ETag per todo UID, refreshed on every
+	// Pull. It is only recorded: Push sends no If-Match precondition, and
+	// conflicts are resolved by LastModified alone (see mergeRemote).
+	etags map[string]string
+	// events is a read-only mirror of each synced calendar's VEVENTs,
+	// keyed by date (the same "2006-01-02" key used for todos) and
+	// replaced wholesale on every Pull, since they're never edited
+	// locally.
+	events map[string][]Event
+}
+
+// Event is a read-only projection of a remote VEVENT, shown in the
+// calendar panel alongside local todos.
+type Event struct {
+	UID   string
+	Title string
+	Start time.Time
+}
+
+// NewCalDAVTodoRepository creates a repository backed by the calendar at
+// opts.Calendar on the CalDAV server at opts.URL. cachePath is where the
+// offline fallback snapshot is read from and written to.
+func NewCalDAVTodoRepository(ctx context.Context, opts Options, cachePath string) (*CalDAVTodoRepository, error) {
+	httpClient := webdav.HTTPClientWithBasicAuth(nil, opts.Username, opts.Password)
+	client, err := caldav.NewClient(httpClient, opts.URL)
+	if err != nil {
+		return nil, fmt.Errorf("sync: connect to %s: %w", opts.URL, err)
+	}
+
+	return &CalDAVTodoRepository{
+		client:    client,
+		calendar:  opts.Calendar,
+		cachePath: cachePath,
+		todos:     make(map[string][]domain.Todo),
+		etags:     make(map[string]string),
+		events:    make(map[string][]Event),
+	}, nil
+}
+
+// ListCalendars returns the calendars available to the account, for use by
+// a first-run calendar picker.
+func (r *CalDAVTodoRepository) ListCalendars(ctx context.Context) ([]caldav.Calendar, error) {
+	homeSet, err := r.client.FindCalendarHomeSet(ctx, "")
+	if err != nil {
+		return nil, fmt.Errorf("sync: find calendar home set: %w", err)
+	}
+	return r.client.FindCalendars(ctx, homeSet)
+}
+
+func (r *CalDAVTodoRepository) GetAll() map[string][]domain.Todo {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.todos
+}
+
+func (r *CalDAVTodoRepository) GetByDate(date time.Time) []domain.Todo {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	todos := r.todos[date.Format(dateLayout)]
+	result := make([]domain.Todo, len(todos))
+	copy(result, todos)
+	return result
+}
+
+func (r *CalDAVTodoRepository) Save(date time.Time, index int, todo domain.Todo) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	key := date.Format(dateLayout)
+	todos, ok := r.todos[key]
+	if !ok || index < 0 || index >= len(todos) {
+		return nil
+	}
+	todo.LastModified = time.Now()
+	todos[index] = todo
+	r.pending = append(r.pending, pendingOp{dateKey: key, uid: todo.UID})
+	return nil
+}
+
+func (r *CalDAVTodoRepository) Add(date time.Time, todo domain.Todo) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	key := date.Format(dateLayout)
+	if todo.UID == "" {
+		todo.UID = domain.NewUID()
+	}
+	todo.LastModified = time.Now()
+	r.todos[key] = append(r.todos[key], todo)
+	r.pending = append(r.pending, pendingOp{dateKey: key, uid: todo.UID})
+	return nil
+}
+
+func (r *CalDAVTodoRepository) Delete(date time.Time, index int) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	key := date.Format(dateLayout)
+	todos, ok := r.todos[key]
+	if !ok || index < 0 || index >= len(todos) {
+		return nil
+	}
+	uid := todos[index].UID
+	r.todos[key] = append(todos[:index], todos[index+1:]...)
+	r.pending = append(r.pending, pendingOp{dateKey: key, uid: uid, deleted: true})
+	return nil
+}
+
+func (r *CalDAVTodoRepository) Reorder(date time.Time, fromIndex, toIndex int) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	key := date.Format(dateLayout)
+	todos, ok := r.todos[key]
+	if !ok {
+		return nil
+	}
+	if fromIndex >= 0 && fromIndex < len(todos) && toIndex >= 0 && toIndex < len(todos) {
+		todos[fromIndex], todos[toIndex] = todos[toIndex], todos[fromIndex]
+	}
+	// Ordering has no CalDAV equivalent; it's a purely local concern.
+	return nil
+}
+
+// Load restores the on-disk offline cache (if any), then attempts to pull
+// the latest state from the remote calendar. A pull failure (e.g. offline
+// at startup) is returned to the caller, but the todos already loaded from
+// disk remain usable in the meantime.
+func (r *CalDAVTodoRepository) Load() error {
+	if err := r.loadCache(); err != nil {
+		return fmt.Errorf("sync: load offline cache: %w", err)
+	}
+	return r.Pull(context.Background())
+}
+
+// Persist mirrors the current cache to disk (so offline edits survive a
+// restart even if the server is unreachable), then flushes any pending
+// local edits to the remote calendar.
+func (r *CalDAVTodoRepository) Persist() error {
+	if err := r.saveCache(); err != nil {
+		return fmt.Errorf("sync: save offline cache: %w", err)
+	}
+	return r.Push(context.Background())
+}
+
+// Import reads a VCALENDAR document from reader and merges each VTODO it
+// contains into the local cache, using the same last-modified-wins
+// conflict resolution as a server Pull. Imported todos are queued for the
+// next Push so they reach the remote calendar too.
+func (r *CalDAVTodoRepository) Import(reader io.Reader) error {
+	cal, err := ical.NewDecoder(reader).Decode()
+	if err != nil {
+		return fmt.Errorf("sync: decode iCalendar: %w", err)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, comp := range cal.Children {
+		if comp.Name != ical.CompToDo {
+			continue
+		}
+		dateKey, todo, err := vtodoToTodo(comp)
+		if err != nil {
+			continue
+		}
+		r.mergeRemote(dateKey, todo)
+		r.pending = append(r.pending, pendingOp{dateKey: dateKey, uid: todo.UID})
+	}
+	return nil
+}
+
+// Export writes every todo in the cache as a VTODO inside a single
+// VCALENDAR document, for interchange with any RFC 5545-compatible tool.
+func (r *CalDAVTodoRepository) Export(w io.Writer) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.exportLocked(w, r.todos)
+}
+
+// ExportRange is like Export but writes only todos whose date key falls in
+// [from, to] (inclusive), for exporting just the range currently visible
+// on the calendar rather than the whole cache.
+func (r *CalDAVTodoRepository) ExportRange(w io.Writer, from, to time.Time) error {
+	from, to = normalizeDay(from), normalizeDay(to)
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	filtered := make(map[string][]domain.Todo)
+	for dateKey, todos := range r.todos {
+		date, err := time.ParseInLocation(dateLayout, dateKey, time.Local)
+		if err != nil || date.Before(from) || date.After(to) {
+			continue
+		}
+		filtered[dateKey] = todos
+	}
+	return r.exportLocked(w, filtered)
+}
+
+// exportLocked writes todos as one VTODO per entry inside a single
+// VCALENDAR document; callers must hold r.mu.
+func (r *CalDAVTodoRepository) exportLocked(w io.Writer, todos map[string][]domain.Todo) error {
+	cal := ical.NewCalendar()
+	cal.Props.SetText(ical.PropVersion, "2.0")
+	cal.Props.SetText(ical.PropProductID, "-//chronoflow//chronoflow//EN")
+
+	for dateKey, dateTodos := range todos {
+		for _, t := range dateTodos {
+			comp, err := todoToVTODO(dateKey, t)
+			if err != nil {
+				return err
+			}
+			cal.Children = append(cal.Children, comp)
+		}
+	}
+	return ical.NewEncoder(w).Encode(cal)
+}
+
+// loadCache reads the offline fallback snapshot from cachePath into the
+// in-memory cache. A missing file is not an error: there's simply nothing
+// to restore yet.
+func (r *CalDAVTodoRepository) loadCache() error {
+	data, err := os.ReadFile(r.cachePath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return err
+	}
+	if len(data) == 0 {
+		return nil
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return json.Unmarshal(data, &r.todos)
+}
+
+// saveCache writes the in-memory cache to cachePath as JSON.
+func (r *CalDAVTodoRepository) saveCache() error {
+	dir := filepath.Dir(r.cachePath)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	data, err := json.MarshalIndent(r.todos, "", "  ")
+	r.mu.Unlock()
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(r.cachePath, data, 0644)
+}
+
+// Pull fetches every VTODO and VEVENT on the configured calendar. VTODOs
+// are merged into the local cache using last-modified-wins conflict
+// resolution (each one's ETag is recorded in etags, though Push does not
+// send it back); VEVENTs simply replace the read-only events mirror, since they're
+// never edited locally.
+func (r *CalDAVTodoRepository) Pull(ctx context.Context) error {
+	query := &caldav.CalendarQuery{
+		CompRequest: caldav.CalendarCompRequest{Name: "VCALENDAR"},
+		CompFilter: caldav.CompFilter{
+			Name:  "VCALENDAR",
+			Comps: []caldav.CompFilter{{Name: "VTODO"}, {Name: "VEVENT"}},
+		},
+	}
+	objects, err := r.client.QueryCalendar(ctx, r.calendar, query)
+	if err != nil {
+		return fmt.Errorf("sync: query calendar: %w", err)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	events := make(map[string][]Event)
+	for _, obj := range objects {
+		for _, comp := range obj.Data.Children {
+			switch comp.Name {
+			case ical.CompToDo:
+				dateKey, remote, err := vtodoToTodo(comp)
+				if err != nil {
+					continue
+				}
+				r.mergeRemote(dateKey, remote)
+				r.etags[remote.UID] = obj.ETag
+			case ical.CompEvent:
+				dateKey, event, err := veventToEvent(comp)
+				if err != nil {
+					continue
+				}
+				events[dateKey] = append(events[dateKey], event)
+			}
+		}
+	}
+	r.events = events
+	return nil
+}
+
+// Events returns the read-only VEVENT mirror built by the last Pull,
+// keyed by date ("2006-01-02"), for display alongside local todos.
+func (r *CalDAVTodoRepository) Events() map[string][]Event {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.events
+}
+
+// PendingUIDs returns the set of todo UIDs with local edits not yet pushed
+// to the server, for a "dirty" indicator in the calendar view.
+func (r *CalDAVTodoRepository) PendingUIDs() map[string]bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	uids := make(map[string]bool, len(r.pending))
+	for _, op := range r.pending {
+		uids[op.uid] = true
+	}
+	return uids
+}
+
+// mergeRemote applies last-modified-wins conflict resolution between a
+// remote VTODO and whatever local copy (if any) shares its UID.
+func (r *CalDAVTodoRepository) mergeRemote(dateKey string, remote domain.Todo) {
+	for key, todos := range r.todos {
+		for i, local := range todos {
+			if local.UID != remote.UID {
+				continue
+			}
+			if !remote.LastModified.After(local.LastModified) {
+				return
+			}
+			if key == dateKey {
+				todos[i] = remote
+				return
+			}
+			r.todos[key] = append(todos[:i], todos[i+1:]...)
+			r.todos[dateKey] = append(r.todos[dateKey], remote)
+			return
+		}
+	}
+	r.todos[dateKey] = append(r.todos[dateKey], remote)
+}
+
+// Push flushes the pending queue, issuing a PUT per touched UID (DELETE for
+// removals) and clears entries that succeed. Entries that fail (offline,
+// server error) are retried on the next Push.
+func (r *CalDAVTodoRepository) Push(ctx context.Context) error {
+	r.mu.Lock()
+	ops := r.pending
+	r.pending = nil
+	r.mu.Unlock()
+
+	var failed []pendingOp
+	for _, op := range ops {
+		if err := r.pushOne(ctx, op); err != nil {
+			failed = append(failed, op)
+		}
+	}
+
+	if len(failed) > 0 {
+		r.mu.Lock()
+		r.pending = append(failed, r.pending...)
+		r.mu.Unlock()
+		return fmt.Errorf("sync: %d change(s) could not be pushed and remain queued", len(failed))
+	}
+	return nil
+}
+
+func (r *CalDAVTodoRepository) objectPath(uid string) string {
+	return r.calendar + uid + ".ics"
+}
+
+func (r *CalDAVTodoRepository) pushOne(ctx context.Context, op pendingOp) error {
+	path := r.objectPath(op.uid)
+
+	if op.deleted {
+		return r.client.RemoveAll(ctx, path)
+	}
+
+	r.mu.Lock()
+	var todo domain.Todo
+	found := false
+	for _, t := range r.todos[op.dateKey] {
+		if t.UID == op.uid {
+			todo, found = t, true
+			break
+		}
+	}
+	r.mu.Unlock()
+	if !found {
+		return nil // already deleted locally between enqueue and flush
+	}
+
+	comp, err := todoToVTODO(op.dateKey, todo)
+	if err != nil {
+		return err
+	}
+
+	cal := ical.NewCalendar()
+	cal.Children = append(cal.Children, comp)
+
+	_, err = r.client.PutCalendarObject(ctx, path, cal)
+	return err
+}
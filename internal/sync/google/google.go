@@ -0,0 +1,254 @@
+// Package google implements internal/sync.SyncProvider against Google
+// Calendar (google.golang.org/api/calendar/v3), as a second backend
+// alongside internal/sync's CalDAVProvider. Like CalDAVTodoRepository, it
+// takes an already-resolved OAuth2 token rather than handling credentials
+// itself (see Options) — main.go loads the token via internal/secrets and
+// writes it back after each sync; nothing refreshes an expired token.
+package google
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"golang.org/x/oauth2"
+	gcal "google.golang.org/api/calendar/v3"
+	"google.golang.org/api/option"
+
+	"ctchen222/chronoflow/internal/domain"
+)
+
+// privatePriorityKey/privateCompleteKey are the extended-property keys
+// chronoflow's own events are tagged with, since Google Calendar events
+// have no native priority/completion concept (that's Google Tasks, a
+// different API) — mirrors how internal/sync/ical.go round-trips
+// domain.Todo.Priority through the iCalendar PRIORITY property.
+const (
+	privatePriorityKey = "chronoflowPriority"
+	privateCompleteKey = "chronoflowComplete"
+)
+
+// Options configures how Provider reaches a Google Calendar account.
+type Options struct {
+	// Token is the already-resolved OAuth2 token for the account; main.go
+	// loads it via internal/secrets before constructing a Provider.
+	Token *oauth2.Token
+	// CalendarID is the calendar to sync against, e.g. "primary" or a
+	// specific calendar's ID as returned by CalendarList.
+	CalendarID string
+}
+
+// Provider implements internal/sync.SyncProvider against a single Google
+// Calendar. Token exposes the token the underlying client uses, which is
+// always the one passed in Options: the static token source never
+// refreshes it.
+type Provider struct {
+	svc        *gcal.Service
+	calendarID string
+	tokenSrc   oauth2.TokenSource
+
+	calendarListCache    []*gcal.CalendarListEntry
+	calendarListCachedAt time.Time
+}
+
+// calendarListTTL bounds how often GetCalendarList actually calls the API;
+// calls within the window return the cached list instead.
+const calendarListTTL = 10 * time.Minute
+
+// NewProvider creates a Provider authenticated with opts.Token.
+func NewProvider(ctx context.Context, opts Options) (*Provider, error) {
+	tokenSrc := oauth2.StaticTokenSource(opts.Token)
+	svc, err := gcal.NewService(ctx, option.WithTokenSource(tokenSrc))
+	if err != nil {
+		return nil, fmt.Errorf("google: create calendar service: %w", err)
+	}
+	return &Provider{svc: svc, calendarID: opts.CalendarID, tokenSrc: tokenSrc}, nil
+}
+
+// Token returns the token currently in use. It is the token the Provider
+// was created with; an expired token is not refreshed.
+func (p *Provider) Token() (*oauth2.Token, error) {
+	return p.tokenSrc.Token()
+}
+
+// Pull fetches every event on the calendar whose start falls in [from, to]
+// and converts each into a domain.Todo keyed by UID, so a later Push of
+// the same todo updates the existing event rather than creating a
+// duplicate.
+func (p *Provider) Pull(ctx context.Context, from, to time.Time) ([]domain.Todo, error) {
+	call := p.svc.Events.List(p.calendarID).
+		TimeMin(from.Format(time.RFC3339)).
+		TimeMax(to.Format(time.RFC3339)).
+		SingleEvents(true).
+		Context(ctx)
+
+	var todos []domain.Todo
+	pageToken := ""
+	for {
+		if pageToken != "" {
+			call = call.PageToken(pageToken)
+		}
+		events, err := call.Do()
+		if err != nil {
+			return nil, fmt.Errorf("google: list events: %w", err)
+		}
+		for _, ev := range events.Items {
+			if ev.Status == "cancelled" || ev.Start == nil {
+				// A cancelled occurrence of a recurring event keeps its
+				// entry in the list (so clients can learn it was deleted)
+				// but has no Start; skip it rather than resurrecting a
+				// deleted occurrence as a live todo.
+				continue
+			}
+			todos = append(todos, eventToTodo(ev))
+		}
+		if events.NextPageToken == "" {
+			break
+		}
+		pageToken = events.NextPageToken
+	}
+	return todos, nil
+}
+
+// Push creates or updates one event per changed todo. A todo whose UID
+// matches an existing event (chronoflow's UID is stored as the event's
+// ICalUID) is updated in place; others are inserted as new events.
+func (p *Provider) Push(ctx context.Context, changes []domain.Todo) error {
+	for _, t := range changes {
+		if t.DueAt.IsZero() {
+			// Calendar events need a concrete date; a todo with neither a
+			// due time nor a due date (DueAt zero either way) has nothing
+			// to schedule against and is skipped rather than pushed as a
+			// garbage 0001-01-01 event.
+			continue
+		}
+		ev := todoToEvent(t)
+		existingID, err := p.findEventID(ctx, t.UID)
+		if err != nil {
+			return fmt.Errorf("google: push %q: %w", t.Title, err)
+		}
+		if existingID != "" {
+			_, err = p.svc.Events.Update(p.calendarID, existingID, ev).Context(ctx).Do()
+		} else {
+			_, err = p.svc.Events.Insert(p.calendarID, ev).Context(ctx).Do()
+		}
+		if err != nil {
+			return fmt.Errorf("google: push %q: %w", t.Title, err)
+		}
+	}
+	return nil
+}
+
+// findEventID looks up the Google event ID (distinct from ICalUID, and
+// what Events.Update needs) already carrying iCalUID, so a re-push of a
+// previously-synced todo updates its event instead of creating a
+// duplicate. Returns "" if no such event exists yet.
+func (p *Provider) findEventID(ctx context.Context, iCalUID string) (string, error) {
+	if iCalUID == "" {
+		return "", nil
+	}
+	events, err := p.svc.Events.List(p.calendarID).ICalUID(iCalUID).Context(ctx).Do()
+	if err != nil {
+		return "", fmt.Errorf("look up existing event for %q: %w", iCalUID, err)
+	}
+	if len(events.Items) == 0 {
+		return "", nil
+	}
+	return events.Items[0].Id, nil
+}
+
+// GetCalendarList returns the calendars available to the account, caching
+// the result for calendarListTTL so a picker UI re-rendering every frame
+// doesn't hit the API on every call.
+func (p *Provider) GetCalendarList(ctx context.Context) ([]*gcal.CalendarListEntry, error) {
+	if !p.calendarListCachedAt.IsZero() && time.Since(p.calendarListCachedAt) < calendarListTTL {
+		return p.calendarListCache, nil
+	}
+	list, err := p.svc.CalendarList.List().Context(ctx).Do()
+	if err != nil {
+		return nil, fmt.Errorf("google: list calendars: %w", err)
+	}
+	p.calendarListCache = list.Items
+	p.calendarListCachedAt = time.Now()
+	return p.calendarListCache, nil
+}
+
+// eventToTodo converts a remote VEVENT-equivalent into a domain.Todo.
+func eventToTodo(ev *gcal.Event) domain.Todo {
+	due, err := time.ParseInLocation(time.RFC3339, ev.Start.DateTime, time.Local)
+	if err != nil {
+		// All-day event: no DateTime/timezone, just a bare date — parsed
+		// in Local like every other date-key parse in this package (see
+		// internal/sync/ical.go), not UTC, so it doesn't drift onto the
+		// wrong local day.
+		due, _ = time.ParseInLocation("2006-01-02", ev.Start.Date, time.Local)
+	}
+	t := domain.Todo{
+		UID:      ev.ICalUID,
+		Title:    ev.Summary,
+		Desc:     ev.Description,
+		Complete: false,
+		DueAt:    due,
+	}
+	if ev.ExtendedProperties != nil {
+		if v, ok := ev.ExtendedProperties.Private[privatePriorityKey]; ok {
+			t.Priority = priorityFromString(v)
+		}
+		if v, ok := ev.ExtendedProperties.Private[privateCompleteKey]; ok {
+			t.Complete = v == "true"
+		}
+	}
+	return t
+}
+
+// todoToEvent is the inverse of eventToTodo. It always produces a timed
+// event: Push already skips any todo with a zero DueAt (which is also
+// every todo with !HasDueTime(), since DueAt is the only place chronoflow
+// records an instant on a bare domain.Todo — the date it's scheduled
+// under otherwise lives in the caller's map[string][]domain.Todo key,
+// which this interface's flat []domain.Todo doesn't carry), so whatever
+// reaches here always has a concrete DueAt to build a timed event from.
+func todoToEvent(t domain.Todo) *gcal.Event {
+	return &gcal.Event{
+		ICalUID:     t.UID,
+		Summary:     t.Title,
+		Description: t.Desc,
+		Start:       &gcal.EventDateTime{DateTime: t.DueAt.Format(time.RFC3339)},
+		End:         &gcal.EventDateTime{DateTime: t.DueAt.Add(time.Hour).Format(time.RFC3339)},
+		ExtendedProperties: &gcal.EventExtendedProperties{
+			Private: map[string]string{
+				privatePriorityKey: priorityToString(t.Priority),
+				privateCompleteKey: fmt.Sprintf("%t", t.Complete),
+			},
+		},
+	}
+}
+
+// priorityToString/priorityFromString round-trip domain.Priority through
+// the extended-property string value (the Calendar API's extended
+// properties are string-only).
+func priorityToString(p domain.Priority) string {
+	switch p {
+	case domain.PriorityHigh:
+		return "high"
+	case domain.PriorityMedium:
+		return "medium"
+	case domain.PriorityLow:
+		return "low"
+	default:
+		return "none"
+	}
+}
+
+func priorityFromString(s string) domain.Priority {
+	switch s {
+	case "high":
+		return domain.PriorityHigh
+	case "medium":
+		return domain.PriorityMedium
+	case "low":
+		return domain.PriorityLow
+	default:
+		return domain.PriorityNone
+	}
+}
@@ -0,0 +1,142 @@
+package sync
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/emersion/go-ical"
+
+	"ctchen222/chronoflow/internal/domain"
+)
+
+// dateLayout matches the date-key format used throughout the repository
+// layer (repository.dateKey / time.Parse("2006-01-02", ...)).
+const dateLayout = "2006-01-02"
+
+// todoToVTODO serializes a domain.Todo scheduled on dateKey into a VTODO
+// component suitable for PUTting to a CalDAV calendar object.
+func todoToVTODO(dateKey string, t domain.Todo) (*ical.Component, error) {
+	due, err := time.ParseInLocation(dateLayout, dateKey, time.Local)
+	if err != nil {
+		return nil, fmt.Errorf("sync: parse date key %q: %w", dateKey, err)
+	}
+
+	comp := ical.NewComponent(ical.CompToDo)
+	comp.Props.SetText(ical.PropUID, t.UID)
+	comp.Props.SetText(ical.PropSummary, t.Title)
+	if t.Desc != "" {
+		comp.Props.SetText(ical.PropDescription, t.Desc)
+	}
+	comp.Props.SetDateTime(ical.PropDue, due)
+	comp.Props.SetText(ical.PropStatus, icalStatus(t.Complete))
+
+	if prio := icalPriority(t.Priority); prio != 0 {
+		comp.Props.Set(&ical.Prop{Name: ical.PropPriority, Value: strconv.Itoa(prio)})
+	}
+
+	modified := t.LastModified
+	if modified.IsZero() {
+		modified = due
+	}
+	comp.Props.SetDateTime(ical.PropLastModified, modified)
+	comp.Props.SetDateTime(ical.PropCreated, modified)
+	comp.Props.SetDateTime(ical.PropDateTimeStamp, modified)
+
+	return comp, nil
+}
+
+// vtodoToTodo converts a remote VTODO component back into a domain.Todo plus
+// the date key it should be filed under (derived from DUE).
+func vtodoToTodo(comp *ical.Component) (dateKey string, t domain.Todo, err error) {
+	uid, err := comp.Props.Text(ical.PropUID)
+	if err != nil {
+		return "", domain.Todo{}, fmt.Errorf("sync: VTODO missing UID: %w", err)
+	}
+	summary, _ := comp.Props.Text(ical.PropSummary)
+	desc, _ := comp.Props.Text(ical.PropDescription)
+	status, _ := comp.Props.Text(ical.PropStatus)
+
+	due, err := comp.Props.DateTime(ical.PropDue, time.Local)
+	if err != nil {
+		return "", domain.Todo{}, fmt.Errorf("sync: VTODO %s missing DUE: %w", uid, err)
+	}
+
+	priority := domain.PriorityNone
+	if prop := comp.Props.Get(ical.PropPriority); prop != nil {
+		if p, err := prop.Int(); err == nil {
+			priority = priorityFromICal(p)
+		}
+	}
+
+	lastModified, err := comp.Props.DateTime(ical.PropLastModified, time.Local)
+	if err != nil {
+		lastModified = due
+	}
+
+	t = domain.Todo{
+		UID:          uid,
+		Title:        summary,
+		Desc:         desc,
+		Complete:     status == "COMPLETED",
+		Priority:     priority,
+		LastModified: lastModified,
+	}
+	return due.Format(dateLayout), t, nil
+}
+
+// veventToEvent converts a remote VEVENT component into a read-only Event
+// plus the date key it should be displayed on (derived from DTSTART).
+func veventToEvent(comp *ical.Component) (dateKey string, e Event, err error) {
+	uid, err := comp.Props.Text(ical.PropUID)
+	if err != nil {
+		return "", Event{}, fmt.Errorf("sync: VEVENT missing UID: %w", err)
+	}
+	summary, _ := comp.Props.Text(ical.PropSummary)
+
+	start, err := comp.Props.DateTime(ical.PropDateTimeStart, time.Local)
+	if err != nil {
+		return "", Event{}, fmt.Errorf("sync: VEVENT %s missing DTSTART: %w", uid, err)
+	}
+
+	return start.Format(dateLayout), Event{UID: uid, Title: summary, Start: start}, nil
+}
+
+// icalStatus maps completion to the iCalendar STATUS values chronoflow
+// understands when round-tripping VTODOs.
+func icalStatus(complete bool) string {
+	if complete {
+		return "COMPLETED"
+	}
+	return "NEEDS-ACTION"
+}
+
+// icalPriority maps chronoflow's three-tier priority onto the iCalendar
+// PRIORITY scale (1=high, 5=mid, 9=low; 0=unset).
+func icalPriority(p domain.Priority) int {
+	switch p {
+	case domain.PriorityHigh:
+		return 1
+	case domain.PriorityMedium:
+		return 5
+	case domain.PriorityLow:
+		return 9
+	default:
+		return 0
+	}
+}
+
+// priorityFromICal is the inverse of icalPriority, rounding values that
+// don't land exactly on 1/5/9 to the nearest bucket.
+func priorityFromICal(p int) domain.Priority {
+	switch {
+	case p == 0:
+		return domain.PriorityNone
+	case p <= 3:
+		return domain.PriorityHigh
+	case p <= 6:
+		return domain.PriorityMedium
+	default:
+		return domain.PriorityLow
+	}
+}
@@ -0,0 +1,74 @@
+package sync
+
+import (
+	"testing"
+	"time"
+
+	"ctchen222/chronoflow/internal/domain"
+)
+
+func TestVTODORoundTrip(t *testing.T) {
+	cases := []struct {
+		name     string
+		priority domain.Priority
+		icalPrio int
+	}{
+		{"high", domain.PriorityHigh, 1},
+		{"medium", domain.PriorityMedium, 5},
+		{"low", domain.PriorityLow, 9},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			want := domain.Todo{
+				UID:          domain.NewUID(),
+				Title:        "Buy milk",
+				Desc:         "2% please",
+				Complete:     true,
+				Priority:     tc.priority,
+				LastModified: time.Date(2026, 3, 5, 9, 30, 0, 0, time.Local),
+			}
+
+			comp, err := todoToVTODO("2026-03-05", want)
+			if err != nil {
+				t.Fatalf("todoToVTODO: %v", err)
+			}
+			prio, _ := comp.Props.Get("PRIORITY").Int()
+			if prio != tc.icalPrio {
+				t.Errorf("PRIORITY = %d, want %d", prio, tc.icalPrio)
+			}
+
+			dateKey, got, err := vtodoToTodo(comp)
+			if err != nil {
+				t.Fatalf("vtodoToTodo: %v", err)
+			}
+			if dateKey != "2026-03-05" {
+				t.Errorf("dateKey = %q, want 2026-03-05", dateKey)
+			}
+			if got.UID != want.UID || got.Title != want.Title || got.Desc != want.Desc ||
+				got.Complete != want.Complete || got.Priority != want.Priority {
+				t.Errorf("round trip mismatch: got %+v, want %+v", got, want)
+			}
+		})
+	}
+}
+
+func TestMergeRemotePrefersNewerLastModified(t *testing.T) {
+	r := &CalDAVTodoRepository{todos: make(map[string][]domain.Todo)}
+	older := domain.Todo{UID: "abc", Title: "old title", LastModified: time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)}
+	newer := domain.Todo{UID: "abc", Title: "new title", LastModified: time.Date(2026, 1, 2, 0, 0, 0, 0, time.UTC)}
+
+	r.todos["2026-01-01"] = []domain.Todo{older}
+	r.mergeRemote("2026-01-01", newer)
+
+	if got := r.todos["2026-01-01"][0].Title; got != "new title" {
+		t.Errorf("expected newer LastModified to win, got title %q", got)
+	}
+
+	// A remote copy older than the local one must not overwrite it.
+	evenOlder := domain.Todo{UID: "abc", Title: "stale title", LastModified: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)}
+	r.mergeRemote("2026-01-01", evenOlder)
+	if got := r.todos["2026-01-01"][0].Title; got != "new title" {
+		t.Errorf("expected local copy to win over stale remote, got title %q", got)
+	}
+}
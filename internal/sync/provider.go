@@ -0,0 +1,64 @@
+package sync
+
+import (
+	"context"
+	"time"
+
+	"ctchen222/chronoflow/internal/domain"
+)
+
+// SyncProvider is the uniform interface every remote calendar backend
+// implements (CalDAVProvider here, internal/sync/google's
+// Provider), so the service layer can merge todos from any
+// number of enabled providers into a single view without caring which
+// backend produced them.
+type SyncProvider interface {
+	// Pull fetches every remote todo scheduled in [from, to].
+	Pull(ctx context.Context, from, to time.Time) ([]domain.Todo, error)
+	// Push uploads local changes (new, edited or completed todos) to the
+	// remote calendar.
+	Push(ctx context.Context, changes []domain.Todo) error
+}
+
+// CalDAVProvider adapts CalDAVTodoRepository to SyncProvider, translating
+// between the repository's per-date map (which the rest of the repository
+// interface needs for GetByDate/Save/etc.) and the flat todo list and
+// explicit date range SyncProvider callers expect.
+type CalDAVProvider struct {
+	repo *CalDAVTodoRepository
+}
+
+// NewCalDAVProvider wraps repo as a SyncProvider.
+func NewCalDAVProvider(repo *CalDAVTodoRepository) *CalDAVProvider {
+	return &CalDAVProvider{repo: repo}
+}
+
+// Pull refreshes repo's local cache from the server, then returns every
+// cached todo whose date key falls in [from, to].
+func (p *CalDAVProvider) Pull(ctx context.Context, from, to time.Time) ([]domain.Todo, error) {
+	if err := p.repo.Pull(ctx); err != nil {
+		return nil, err
+	}
+	from, to = normalizeDay(from), normalizeDay(to)
+
+	var todos []domain.Todo
+	for dateKey, items := range p.repo.GetAll() {
+		date, err := time.ParseInLocation(dateLayout, dateKey, time.Local)
+		if err != nil || date.Before(from) || date.After(to) {
+			continue
+		}
+		todos = append(todos, items...)
+	}
+	return todos, nil
+}
+
+// Push flushes repo's pending local edits to the server. changes is
+// unused: CalDAVTodoRepository already tracks which todos need pushing
+// itself (see pendingOp), recorded as each one is saved/added/deleted.
+func (p *CalDAVProvider) Push(ctx context.Context, changes []domain.Todo) error {
+	return p.repo.Push(ctx)
+}
+
+func normalizeDay(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+}
@@ -20,8 +20,18 @@ func NewCalendarAdapter(statsCalc *service.StatsCalculator) *CalendarAdapter {
 	}
 }
 
-// BuildTodoStatus builds the todo status map for calendar display
-func (a *CalendarAdapter) BuildTodoStatus(allTodos map[string][]domain.Todo) map[string]calendar.TodoStatus {
+// BuildTodoStatus builds the todo status map for calendar display. dirty
+// is the set of todo UIDs with local edits not yet pushed to a CalDAV
+// server (nil when no remote sync is active), surfaced as a small marker
+// on the affected TodoItem. remote holds todos pulled from any other
+// enabled sync.SyncProvider (e.g. Google Calendar) that isn't already
+// folded into allTodos — CalDAV doesn't go through remote, since
+// CalDAVTodoRepository is allTodos' backing TodoRepository in that mode
+// and already merges remote VTODOs at the repository layer. remote is
+// merged in as read-only items, keyed the same way as allTodos, so the
+// calendar shows one combined view regardless of where each todo came
+// from.
+func (a *CalendarAdapter) BuildTodoStatus(allTodos map[string][]domain.Todo, dirty map[string]bool, remote map[string][]domain.Todo) map[string]calendar.TodoStatus {
 	todoStatus := make(map[string]calendar.TodoStatus)
 
 	for dateKey, items := range allTodos {
@@ -42,21 +52,16 @@ func (a *CalendarAdapter) BuildTodoStatus(allTodos map[string][]domain.Todo) map
 		// Convert items to calendar.TodoItem for week/day view display
 		calendarItems := make([]calendar.TodoItem, len(items))
 		for i, it := range items {
-			startTime := ""
-			endTime := ""
-			if it.StartTime != nil {
-				startTime = *it.StartTime
-			}
-			if it.EndTime != nil {
-				endTime = *it.EndTime
+			dueTime := ""
+			if it.HasDueTime() {
+				dueTime = it.DueAt.Format("15:04")
 			}
 			calendarItems[i] = calendar.TodoItem{
-				Title:     it.Title,
-				Desc:      it.Desc,
-				Complete:  it.Complete,
-				Priority:  int(it.Priority),
-				StartTime: startTime,
-				EndTime:   endTime,
+				Title:    it.Title,
+				Complete: it.Complete,
+				Priority: int(it.Priority),
+				Dirty:    dirty[it.UID],
+				DueTime:  dueTime,
 			}
 		}
 
@@ -69,12 +74,93 @@ func (a *CalendarAdapter) BuildTodoStatus(allTodos map[string][]domain.Todo) map
 		}
 	}
 
+	a.mergeRemoteTodos(todoStatus, remote)
+
 	return todoStatus
 }
 
-// ConvertViewMode converts calendar.ViewMode to service.ViewMode
+// mergeRemoteTodos appends each remote-provider todo as a read-only
+// TodoItem on its date, alongside whatever BuildTodoStatus already built
+// from local todos — mirrors how cmd/chronoflow's mergeCalDAVEvents folds
+// read-only VEVENTs into the same map. AllComplete and HasOverdue are
+// recomputed across the merged item list rather than left at whatever
+// BuildTodoStatus computed from local todos alone, so a remote todo can't
+// be silently dropped from that day's status (or, for a remote-only day,
+// leave both at their false zero value despite the remote items actually
+// being complete/overdue).
+func (a *CalendarAdapter) mergeRemoteTodos(todoStatus map[string]calendar.TodoStatus, remote map[string][]domain.Todo) {
+	for dateKey, items := range remote {
+		if len(items) == 0 {
+			continue
+		}
+		date, err := time.Parse("2006-01-02", dateKey)
+		if err != nil {
+			continue
+		}
+
+		status := todoStatus[dateKey]
+		for _, it := range items {
+			status.Items = append(status.Items, calendar.TodoItem{
+				Title:    it.Title,
+				Complete: it.Complete,
+				Priority: int(it.Priority),
+				ReadOnly: true,
+			})
+		}
+		status.HasTodos = true
+		status.HasOverdue = status.HasOverdue || a.statsCalc.IsDateOverdue(items, date)
+		status.Count += len(items)
+		status.AllComplete = allItemsComplete(status.Items)
+		todoStatus[dateKey] = status
+	}
+}
+
+// allItemsComplete reports whether every item in items is complete. An
+// empty list reports false, matching calendar.TodoStatus's zero value for
+// a day with nothing on it.
+func allItemsComplete(items []calendar.TodoItem) bool {
+	if len(items) == 0 {
+		return false
+	}
+	for _, it := range items {
+		if !it.Complete {
+			return false
+		}
+	}
+	return true
+}
+
+// BuildSpans collects every multi-day todo in allTodos into the
+// calendar.SpanItem form week view renders as a continuous bar. allTodos
+// should be the raw, un-expanded map (e.g. TodoService.GetAllTodos), since
+// each todo's date key here is taken as its span's start date.
+func (a *CalendarAdapter) BuildSpans(allTodos map[string][]domain.Todo) []calendar.SpanItem {
+	var spans []calendar.SpanItem
+	for dateKey, items := range allTodos {
+		start, err := time.Parse("2006-01-02", dateKey)
+		if err != nil {
+			continue
+		}
+		for _, it := range items {
+			if !it.HasEndDate() {
+				continue
+			}
+			spans = append(spans, calendar.SpanItem{
+				Title:    it.Title,
+				Complete: it.Complete,
+				Priority: int(it.Priority),
+				Start:    start,
+				End:      it.EndDate,
+			})
+		}
+	}
+	return spans
+}
+
+// ConvertViewMode converts calendar.ViewMode to service.ViewMode. Agenda view
+// has no period of its own for stats purposes, so it reuses WeekView's.
 func ConvertViewMode(vm calendar.ViewMode) service.ViewMode {
-	if vm == calendar.WeekView {
+	if vm == calendar.WeekView || vm == calendar.AgendaView {
 		return service.WeekView
 	}
 	return service.MonthView
@@ -0,0 +1,46 @@
+package ui
+
+import (
+	"testing"
+	"time"
+
+	"ctchen222/chronoflow/internal/domain"
+	"ctchen222/chronoflow/internal/service"
+)
+
+func TestBuildTodoStatusMergesRemoteTodosReadOnly(t *testing.T) {
+	tp := service.NewMockTimeProvider(time.Date(2026, 1, 10, 0, 0, 0, 0, time.Local))
+	a := NewCalendarAdapter(service.NewStatsCalculator(tp))
+
+	local := map[string][]domain.Todo{
+		"2026-01-10": {{UID: "a", Title: "Local todo"}},
+	}
+	remote := map[string][]domain.Todo{
+		"2026-01-10": {{UID: "g1", Title: "Google event", Complete: true}},
+		"2026-01-11": {{UID: "g2", Title: "Google-only day"}},
+	}
+
+	status := a.BuildTodoStatus(local, nil, remote)
+
+	mixed, ok := status["2026-01-10"]
+	if !ok || mixed.Count != 2 {
+		t.Fatalf("2026-01-10 = %+v, want local+remote merged to count 2", mixed)
+	}
+	var foundRemote bool
+	for _, it := range mixed.Items {
+		if it.Title == "Google event" {
+			foundRemote = true
+			if !it.ReadOnly {
+				t.Error("remote todo should be marked ReadOnly")
+			}
+		}
+	}
+	if !foundRemote {
+		t.Errorf("items = %+v, want the remote todo present", mixed.Items)
+	}
+
+	remoteOnly, ok := status["2026-01-11"]
+	if !ok || !remoteOnly.HasTodos || remoteOnly.Count != 1 {
+		t.Fatalf("2026-01-11 = %+v, want a remote-only day with HasTodos and count 1", remoteOnly)
+	}
+}
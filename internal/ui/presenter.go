@@ -1,8 +1,13 @@
 package ui
 
 import (
+	"fmt"
+	"strings"
+	"time"
+
 	"ctchen222/chronoflow/internal/domain"
 	"ctchen222/chronoflow/internal/service"
+	"ctchen222/chronoflow/internal/ui/theme"
 
 	"github.com/charmbracelet/bubbles/list"
 	"github.com/charmbracelet/lipgloss"
@@ -13,6 +18,27 @@ import (
 type TodoItem struct {
 	domain.Todo
 	IsOverdue bool
+
+	// IsInstance, ParentDate and ParentIndex locate the stored master todo
+	// for a recurring occurrence; see service.TodoWithStatus.
+	IsInstance  bool
+	ParentDate  time.Time
+	ParentIndex int
+
+	// Depth, HasChildren, IsLastChild and Collapsed drive the subtask tree
+	// rendering: IsLastChild picks "└─" over "├─" for the connector glyph.
+	Depth       int
+	HasChildren bool
+	IsLastChild bool
+	Collapsed   bool
+
+	// SubtasksDone/SubtasksTotal count this item's direct children (not
+	// further descendants); SubtasksTotal is 0 for a leaf todo.
+	SubtasksDone  int
+	SubtasksTotal int
+
+	// Theme colors this item's rendering; set by TodoPresenter.ToListItems.
+	Theme theme.Theme
 }
 
 // Title returns the formatted title for display
@@ -22,28 +48,62 @@ func (i TodoItem) Title() string {
 
 	if i.Complete {
 		checkbox = "☑ "
-		style = style.Foreground(lipgloss.Color("#666")).Strikethrough(true)
+		style = style.Foreground(lipgloss.Color(i.Theme.TextMuted)).Strikethrough(true)
 	} else if i.IsOverdue {
 		checkbox = "⚠ "
-		style = style.Foreground(lipgloss.Color("#FF6B6B")).Bold(true)
+		style = style.Foreground(lipgloss.Color(i.Theme.Danger)).Bold(true)
 	} else if i.Priority > 0 {
-		style = priorityStyle(i.Priority)
+		style = priorityStyle(i.Priority, i.Theme)
 	}
 
 	title := style.Render(i.Todo.Title)
 	if i.Priority > 0 && !i.Complete {
-		title = priorityStyle(i.Priority).Render(i.Priority.Icon()) + " " + title
+		title = priorityStyle(i.Priority, i.Theme).Render(i.Priority.Icon()) + " " + title
+	}
+	if i.HasDueTime() {
+		dueStyle := lipgloss.NewStyle().Foreground(lipgloss.Color(i.Theme.TextDim))
+		title = dueStyle.Render(i.DueAt.Format("15:04")) + " " + title
 	}
 
-	return checkbox + title
+	return i.treePrefix() + checkbox + title
+}
+
+// treePrefix renders the indentation/connector glyph and collapse
+// indicator for a subtask tree row.
+func (i TodoItem) treePrefix() string {
+	var prefix string
+	if i.Depth > 0 {
+		connector := "├─ "
+		if i.IsLastChild {
+			connector = "└─ "
+		}
+		prefix = strings.Repeat("  ", i.Depth-1) + connector
+	}
+	if i.HasChildren {
+		if i.Collapsed {
+			prefix += "▸ "
+		} else {
+			prefix += "▾ "
+		}
+	}
+	return prefix
 }
 
 // Description returns the formatted description for display
 func (i TodoItem) Description() string {
+	desc := i.Desc
+	if i.HasChildren {
+		progress := fmt.Sprintf("%d/%d subtasks done", i.SubtasksDone, i.SubtasksTotal)
+		if desc != "" {
+			desc = progress + "  " + desc
+		} else {
+			desc = progress
+		}
+	}
 	if i.IsOverdue && !i.Complete {
-		return lipgloss.NewStyle().Foreground(lipgloss.Color("#FF6B6B")).Render("Overdue! " + i.Desc)
+		return lipgloss.NewStyle().Foreground(lipgloss.Color(i.Theme.Danger)).Render("Overdue! " + desc)
 	}
-	return i.Desc
+	return desc
 }
 
 // FilterValue returns the value used for filtering
@@ -52,35 +112,85 @@ func (i TodoItem) FilterValue() string {
 }
 
 // priorityStyle returns the lipgloss style for a priority level
-func priorityStyle(p domain.Priority) lipgloss.Style {
+func priorityStyle(p domain.Priority, th theme.Theme) lipgloss.Style {
 	switch p {
 	case domain.PriorityHigh:
-		return lipgloss.NewStyle().Foreground(lipgloss.Color("#FF6B6B")).Bold(true)
+		return lipgloss.NewStyle().Foreground(lipgloss.Color(th.Danger)).Bold(true)
 	case domain.PriorityMedium:
-		return lipgloss.NewStyle().Foreground(lipgloss.Color("#FFB86C"))
+		return lipgloss.NewStyle().Foreground(lipgloss.Color(th.Warning))
 	case domain.PriorityLow:
-		return lipgloss.NewStyle().Foreground(lipgloss.Color("#8BE9FD"))
+		return lipgloss.NewStyle().Foreground(lipgloss.Color(th.Info))
 	default:
 		return lipgloss.NewStyle()
 	}
 }
 
 // TodoPresenter converts service data to UI-ready format
-type TodoPresenter struct{}
+type TodoPresenter struct {
+	theme theme.Theme
+}
+
+// NewTodoPresenter creates a new TodoPresenter that renders with th.
+func NewTodoPresenter(th theme.Theme) *TodoPresenter {
+	return &TodoPresenter{theme: th}
+}
 
-// NewTodoPresenter creates a new TodoPresenter
-func NewTodoPresenter() *TodoPresenter {
-	return &TodoPresenter{}
+// SetTheme swaps the presenter's theme, taking effect on the next
+// ToListItems call — used by the live theme-picker modal.
+func (p *TodoPresenter) SetTheme(th theme.Theme) {
+	p.theme = th
 }
 
-// ToListItems converts TodoWithStatus slice to list.Item slice
-func (p *TodoPresenter) ToListItems(todos []service.TodoWithStatus) []list.Item {
-	items := make([]list.Item, len(todos))
-	for i, td := range todos {
-		items[i] = TodoItem{
-			Todo:      td.Todo,
-			IsOverdue: td.IsOverdue,
+// ToListItems converts a TodoWithStatus slice (already in tree order, see
+// service.TodoService.GetTodosForDate) into list.Item values, collapsing
+// the descendants of any UID present in collapsed.
+func (p *TodoPresenter) ToListItems(todos []service.TodoWithStatus, collapsed map[string]bool) []list.Item {
+	childCount := make(map[string]int)
+	childDone := make(map[string]int)
+	for _, td := range todos {
+		if td.ParentUID != "" {
+			childCount[td.ParentUID]++
+			if td.Complete {
+				childDone[td.ParentUID]++
+			}
+		}
+	}
+
+	items := make([]list.Item, 0, len(todos))
+	hiddenBelowDepth := -1 // -1 means nothing is currently being hidden
+	childSeen := make(map[string]int)
+	for _, td := range todos {
+		if hiddenBelowDepth >= 0 {
+			if td.Depth > hiddenBelowDepth {
+				continue // inside a collapsed subtree
+			}
+			hiddenBelowDepth = -1
+		}
+
+		isLastChild := true
+		if td.ParentUID != "" {
+			childSeen[td.ParentUID]++
+			isLastChild = childSeen[td.ParentUID] == childCount[td.ParentUID]
+		}
+
+		item := TodoItem{
+			Todo:          td.Todo,
+			IsOverdue:     td.IsOverdue,
+			IsInstance:    td.IsInstance,
+			ParentDate:    td.ParentDate,
+			ParentIndex:   td.ParentIndex,
+			Depth:         td.Depth,
+			HasChildren:   childCount[td.UID] > 0,
+			IsLastChild:   isLastChild,
+			SubtasksDone:  childDone[td.UID],
+			SubtasksTotal: childCount[td.UID],
+			Theme:         p.theme,
+		}
+		if item.HasChildren && collapsed[td.UID] {
+			item.Collapsed = true
+			hiddenBelowDepth = td.Depth
 		}
+		items = append(items, item)
 	}
 	return items
 }
@@ -95,9 +205,9 @@ type PriorityOption struct {
 // GetPriorityOptions returns the available priority options
 func (p *TodoPresenter) GetPriorityOptions() []PriorityOption {
 	return []PriorityOption{
-		{domain.PriorityNone, "None", "#666"},
-		{domain.PriorityLow, "Low", "#8BE9FD"},
-		{domain.PriorityMedium, "Medium", "#FFB86C"},
-		{domain.PriorityHigh, "High", "#FF6B6B"},
+		{domain.PriorityNone, "None", p.theme.TextMuted},
+		{domain.PriorityLow, "Low", p.theme.Info},
+		{domain.PriorityMedium, "Medium", p.theme.Warning},
+		{domain.PriorityHigh, "High", p.theme.Danger},
 	}
 }
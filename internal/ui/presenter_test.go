@@ -0,0 +1,60 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"ctchen222/chronoflow/internal/domain"
+)
+
+func TestTodoItemTitleGlyphAndDuePrefix(t *testing.T) {
+	dueAt := time.Date(0, 1, 1, 9, 30, 0, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		item     TodoItem
+		wantHas  []string
+		wantMiss []string
+	}{
+		{
+			name:     "high priority, no due time",
+			item:     TodoItem{Todo: domain.Todo{Title: "Ship release", Priority: domain.PriorityHigh}},
+			wantHas:  []string{domain.PriorityHigh.Icon(), "Ship release"},
+			wantMiss: []string{"09:30"},
+		},
+		{
+			name:    "medium priority with due time",
+			item:    TodoItem{Todo: domain.Todo{Title: "Stand-up", Priority: domain.PriorityMedium, DueAt: dueAt}},
+			wantHas: []string{domain.PriorityMedium.Icon(), "09:30", "Stand-up"},
+		},
+		{
+			name:     "low priority, completed hides priority glyph",
+			item:     TodoItem{Todo: domain.Todo{Title: "Archive notes", Priority: domain.PriorityLow, Complete: true}},
+			wantHas:  []string{"Archive notes"},
+			wantMiss: []string{domain.PriorityLow.Icon()},
+		},
+		{
+			name:     "no priority, no due time",
+			item:     TodoItem{Todo: domain.Todo{Title: "Read changelog"}},
+			wantHas:  []string{"Read changelog"},
+			wantMiss: []string{"09:30"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.item.Title()
+			for _, want := range tt.wantHas {
+				if !strings.Contains(got, want) {
+					t.Errorf("Title() = %q, want substring %q", got, want)
+				}
+			}
+			for _, miss := range tt.wantMiss {
+				if strings.Contains(got, miss) {
+					t.Errorf("Title() = %q, unexpectedly contains %q", got, miss)
+				}
+			}
+		})
+	}
+}
@@ -5,6 +5,7 @@ import (
 
 	"ctchen222/chronoflow/internal/domain"
 	"ctchen222/chronoflow/internal/service"
+	"ctchen222/chronoflow/internal/ui/theme"
 )
 
 // AppState defines the current state of the application
@@ -15,6 +16,24 @@ const (
 	StateEditing
 	StateConfirmingDelete
 	StateSearching
+	// StateSelectingCalendar is shown on first run when CalDAV sync is
+	// enabled but no remote calendar has been chosen yet.
+	StateSelectingCalendar
+	// StateLoggingIn is shown on first run when CalDAV sync is enabled but
+	// no credentials were found in the keyring (or its file fallback) and
+	// the CHRONOFLOW_CALDAV_PASSWORD escape hatch isn't set.
+	StateLoggingIn
+	// StateEditScope is shown before StateEditing when editing an existing
+	// occurrence of a recurring todo, asking whether the edit applies to
+	// just this occurrence, this and future occurrences, or the whole
+	// series.
+	StateEditScope
+	// StateSelectingTheme is shown when the user opens the theme-picker
+	// modal (the T keybind), previewing each theme live as it's selected.
+	StateSelectingTheme
+	// StateNamingView is shown after Ctrl+S in the search modal, prompting
+	// for a name before the current query is saved as a SavedView.
+	StateNamingView
 )
 
 // AppFocus determines which panel is currently focused
@@ -23,6 +42,8 @@ type AppFocus int
 const (
 	FocusCalendar AppFocus = iota
 	FocusTodo
+	// FocusViews is the saved-views column listing persisted searches.
+	FocusViews
 )
 
 // EditFocus determines which input is focused in the editing view
@@ -31,34 +52,181 @@ type EditFocus int
 const (
 	FocusTitle EditFocus = iota
 	FocusDesc
+	// FocusDueTime is the "HH:MM" time-of-day input, cycled past with the
+	// alarm offset via Ctrl+A (see AlarmPreset).
+	FocusDueTime
+	// FocusRecurrence is only reachable when RecurrencePreset is
+	// RecurrenceCustom, where it lets the user type a raw RRULE string.
+	FocusRecurrence
 )
 
+// AlarmPreset is a named reminder lead time, offered as a Ctrl+A cycle next
+// to the due-time input the same way RecurrencePreset cycles with Ctrl+R.
+type AlarmPreset int
+
+const (
+	AlarmNone AlarmPreset = iota
+	Alarm5Min
+	Alarm10Min
+	Alarm30Min
+	Alarm1Hour
+	alarmPresetCount
+)
+
+// Label returns the preset's display name for the editing modal.
+func (p AlarmPreset) Label() string {
+	switch p {
+	case Alarm5Min:
+		return "5m"
+	case Alarm10Min:
+		return "10m"
+	case Alarm30Min:
+		return "30m"
+	case Alarm1Hour:
+		return "1h"
+	default:
+		return "Off"
+	}
+}
+
+// Offset returns the preset as a time.Duration, 0 for AlarmNone.
+func (p AlarmPreset) Offset() time.Duration {
+	switch p {
+	case Alarm5Min:
+		return 5 * time.Minute
+	case Alarm10Min:
+		return 10 * time.Minute
+	case Alarm30Min:
+		return 30 * time.Minute
+	case Alarm1Hour:
+		return time.Hour
+	default:
+		return 0
+	}
+}
+
+// Next cycles to the following preset, wrapping back to AlarmNone.
+func (p AlarmPreset) Next() AlarmPreset {
+	return (p + 1) % alarmPresetCount
+}
+
+// RecurrencePreset is a simple named recurrence pattern offered in the
+// editing modal; RecurrenceCustom lets the user type an arbitrary RRULE
+// (optionally with an EXDATE clause) directly.
+type RecurrencePreset int
+
+const (
+	RecurrenceNone RecurrencePreset = iota
+	RecurrenceDaily
+	RecurrenceWeekdays
+	RecurrenceWeekly
+	RecurrenceMonthly
+	RecurrenceYearly
+	RecurrenceCustom
+	recurrencePresetCount
+)
+
+// Label returns the preset's display name for the editing modal.
+func (p RecurrencePreset) Label() string {
+	switch p {
+	case RecurrenceDaily:
+		return "Daily"
+	case RecurrenceWeekdays:
+		return "Weekdays"
+	case RecurrenceWeekly:
+		return "Weekly"
+	case RecurrenceMonthly:
+		return "Monthly"
+	case RecurrenceYearly:
+		return "Yearly"
+	case RecurrenceCustom:
+		return "Custom"
+	default:
+		return "None"
+	}
+}
+
+// Next cycles to the following preset, wrapping back to RecurrenceNone.
+func (p RecurrencePreset) Next() RecurrencePreset {
+	return (p + 1) % recurrencePresetCount
+}
+
 // EditingState holds the state for the editing modal
 type EditingState struct {
-	IsNew       bool
-	Date        time.Time
-	TitleValue  string
-	DescValue   string
-	Priority    domain.Priority
-	Focus       EditFocus
-	TitleView   string // rendered title input
-	DescView    string // rendered desc input
+	IsNew      bool
+	Date       time.Time
+	TitleValue string
+	DescValue  string
+	Priority   domain.Priority
+	Focus      EditFocus
+	TitleView  string // rendered title input
+	DescView   string // rendered desc input
 	// Preview fields
 	PreviewEnabled bool   // whether preview pane is visible
 	PreviewContent string // rendered markdown preview
+
+	// RecurrencePreset is the currently selected repeat pattern.
+	RecurrencePreset RecurrencePreset
+	// CustomRRuleView is the rendered raw-RRULE input, shown only when
+	// RecurrencePreset is RecurrenceCustom.
+	CustomRRuleView string
+
+	// DueTimeValue is the raw "HH:MM" due-time input value; DueTimeView is
+	// its rendered textinput form. DueTimeValue empty means no time of day
+	// has been set yet.
+	DueTimeValue string
+	DueTimeView  string
+	// AlarmPreset is the currently selected reminder lead time, only
+	// meaningful once a due time has been entered.
+	AlarmPreset AlarmPreset
+
+	// SubtaskOf is the parent todo's title, set only when this modal is
+	// creating a subtask; empty otherwise.
+	SubtaskOf string
+}
+
+// EditScopeState holds the state for the "this event / this and future /
+// all events" scope picker shown when editing or deleting an occurrence of
+// a recurring todo.
+type EditScopeState struct {
+	Title  string
+	Cursor int
+	// IsDelete distinguishes the picker shown in front of a delete from
+	// the one shown in front of an edit, so the header doesn't claim
+	// "Edit" right before a destructive action.
+	IsDelete bool
 }
 
+// EditScope identifies which occurrences of a recurring todo an edit
+// applies to.
+type EditScope int
+
+const (
+	ScopeThisEvent EditScope = iota
+	ScopeThisAndFuture
+	ScopeAllEvents
+)
+
+// EditScopeChoices are the options shown by the scope picker, in the
+// order selected by EditScopeState.Cursor.
+var EditScopeChoices = []string{"This event", "This and following events", "All events"}
+
 // DeleteState holds the state for the delete confirmation modal
 type DeleteState struct {
 	Title string
+	// Scope, if non-empty, restates which occurrences of a recurring
+	// todo are about to be deleted (one of EditScopeChoices), since the
+	// choice was already made one screen back in StateEditScope.
+	Scope string
 }
 
 // SearchState holds the state for the search modal
 type SearchState struct {
-	InputView   string // rendered search input
-	InputValue  string
-	Results     []service.SearchResult
-	SelectedIdx int
+	InputView     string // rendered search input
+	InputValue    string
+	Results       []service.SearchResult
+	SelectedIdx   int
+	CaseSensitive bool
 }
 
 // MainViewState holds the state for the main view
@@ -66,4 +234,44 @@ type MainViewState struct {
 	CalendarView string
 	TodoView     string
 	Focus        AppFocus
+	// Views, ViewsCursor, and ActiveViewUID drive the saved-views column
+	// rendered alongside the calendar and todo panels (see RenderMain).
+	Views         []domain.SavedView
+	ViewsCursor   int
+	ActiveViewUID string
+}
+
+// NameViewState holds the state for the "name this saved view" prompt,
+// shown after Ctrl+S in the search modal.
+type NameViewState struct {
+	Query     string
+	InputView string
+}
+
+// ThemePickerState holds the state for the theme-picker modal. Selecting a
+// different theme is applied immediately to the ViewRenderer for live
+// preview; Esc restores Previous.
+type ThemePickerState struct {
+	Choices  []theme.Theme
+	Cursor   int
+	Previous theme.Theme
+}
+
+// CalendarChoice is one selectable remote calendar in the first-run CalDAV
+// picker. It's a narrow projection of caldav.Calendar so this package
+// doesn't need to depend on the CalDAV client library.
+type CalendarChoice struct {
+	Path        string
+	DisplayName string
+}
+
+// CalendarPickerState holds the state for the first-run "pick a calendar"
+// modal shown when CalDAV sync is enabled but Config.CalDAV.Calendar is
+// still unset.
+type CalendarPickerState struct {
+	Choices []CalendarChoice
+	Cursor  int
+	// Err, if non-empty, is a discovery failure shown instead of the list
+	// (e.g. the server was unreachable at startup).
+	Err string
 }
@@ -0,0 +1,70 @@
+package theme
+
+import (
+	"bufio"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/muesli/termenv"
+)
+
+// themeEnvVar, when set, names a built-in theme (see ByName) to use
+// regardless of the config file or terminal color support.
+const themeEnvVar = "CHRONOFLOW_THEME"
+
+// Load resolves the theme to use at startup: a CHRONOFLOW_THEME env var
+// takes priority, then a "theme" key in ~/.config/chronoflow/theme.toml,
+// then Monochrome if the terminal doesn't support color, else Default.
+func Load() Theme {
+	if name := os.Getenv(themeEnvVar); name != "" {
+		if t, ok := ByName(strings.ToLower(strings.TrimSpace(name))); ok {
+			return t
+		}
+	}
+
+	if name, ok := readConfigFile(); ok {
+		if t, ok := ByName(strings.ToLower(strings.TrimSpace(name))); ok {
+			return t
+		}
+	}
+
+	if termenv.ColorProfile() == termenv.Ascii {
+		return Monochrome
+	}
+	return Default()
+}
+
+// readConfigFile reads the "theme" key out of ~/.config/chronoflow/theme.toml.
+// Only a single flat `theme = "name"` assignment is supported — chronoflow
+// hand-rolls small parsers like this rather than taking on a TOML dependency
+// (see domain.ParseRRule for the same precedent).
+func readConfigFile() (string, bool) {
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return "", false
+	}
+	path := filepath.Join(homeDir, ".config", "chronoflow", "theme.toml")
+
+	f, err := os.Open(path)
+	if err != nil {
+		return "", false
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		key, val, found := strings.Cut(line, "=")
+		if !found || strings.TrimSpace(key) != "theme" {
+			continue
+		}
+		val = strings.TrimSpace(val)
+		val = strings.Trim(val, `"'`)
+		return val, val != ""
+	}
+	return "", false
+}
@@ -0,0 +1,161 @@
+// Package theme holds chronoflow's color palettes. internal/ui reads every
+// color it renders from a *Theme rather than hardcoding hex values, so a
+// user can swap the whole look at startup via Load.
+package theme
+
+// Theme is a named color palette. Every field is a lipgloss-compatible hex
+// string ("#RRGGBB"). Field names describe the color's role, not a specific
+// hue, so a theme can reassign what "Danger" or "Accent" look like without
+// touching any rendering code.
+type Theme struct {
+	Name string
+
+	Accent  string // focused borders, primary headers
+	Success string // "new" accent, low-priority-adjacent positive state
+	Danger  string // overdue, delete, high priority
+	Warning string // medium priority, search accent
+	Info    string // low priority, CalDAV setup accents
+
+	TextBright string // emphasized text (e.g. delete confirmation title)
+	TextDim    string // secondary labels
+	TextMuted  string // least prominent text (completed items, hints)
+
+	Border     string // unfocused panel/input borders
+	Overlay    string // modal backdrop
+	Background string // help bar background
+}
+
+// Default is the palette chronoflow shipped with before themes existed;
+// Load falls back to it when no other theme is selected.
+func Default() Theme { return Dracula }
+
+// Dracula is chronoflow's original, default palette.
+var Dracula = Theme{
+	Name:       "dracula",
+	Accent:     "#7D56F4",
+	Success:    "#50FA7B",
+	Danger:     "#FF6B6B",
+	Warning:    "#FFB86C",
+	Info:       "#8BE9FD",
+	TextBright: "#FFFFFF",
+	TextDim:    "#888888",
+	TextMuted:  "#666666",
+	Border:     "#444444",
+	Overlay:    "#333333",
+	Background: "#1a1a1a",
+}
+
+// Nord is based on the Nord color scheme (nordtheme.com).
+var Nord = Theme{
+	Name:       "nord",
+	Accent:     "#88C0D0",
+	Success:    "#A3BE8C",
+	Danger:     "#BF616A",
+	Warning:    "#D08770",
+	Info:       "#81A1C1",
+	TextBright: "#ECEFF4",
+	TextDim:    "#D8DEE9",
+	TextMuted:  "#4C566A",
+	Border:     "#3B4252",
+	Overlay:    "#2E3440",
+	Background: "#2E3440",
+}
+
+// SolarizedDark is based on Ethan Schoonover's Solarized palette.
+var SolarizedDark = Theme{
+	Name:       "solarized-dark",
+	Accent:     "#268BD2",
+	Success:    "#859900",
+	Danger:     "#DC322F",
+	Warning:    "#CB4B16",
+	Info:       "#2AA198",
+	TextBright: "#FDF6E3",
+	TextDim:    "#93A1A1",
+	TextMuted:  "#586E75",
+	Border:     "#073642",
+	Overlay:    "#002B36",
+	Background: "#002B36",
+}
+
+// SolarizedLight is the light variant of SolarizedDark.
+var SolarizedLight = Theme{
+	Name:       "solarized-light",
+	Accent:     "#268BD2",
+	Success:    "#859900",
+	Danger:     "#DC322F",
+	Warning:    "#CB4B16",
+	Info:       "#2AA198",
+	TextBright: "#073642",
+	TextDim:    "#586E75",
+	TextMuted:  "#93A1A1",
+	Border:     "#EEE8D5",
+	Overlay:    "#FDF6E3",
+	Background: "#EEE8D5",
+}
+
+// Gruvbox is based on the Gruvbox color scheme's dark variant.
+var Gruvbox = Theme{
+	Name:       "gruvbox",
+	Accent:     "#83A598",
+	Success:    "#B8BB26",
+	Danger:     "#FB4934",
+	Warning:    "#FE8019",
+	Info:       "#8EC07C",
+	TextBright: "#EBDBB2",
+	TextDim:    "#A89984",
+	TextMuted:  "#665C54",
+	Border:     "#3C3836",
+	Overlay:    "#282828",
+	Background: "#282828",
+}
+
+// Catppuccin is based on the Catppuccin Mocha palette.
+var Catppuccin = Theme{
+	Name:       "catppuccin",
+	Accent:     "#CBA6F7",
+	Success:    "#A6E3A1",
+	Danger:     "#F38BA8",
+	Warning:    "#FAB387",
+	Info:       "#89DCEB",
+	TextBright: "#CDD6F4",
+	TextDim:    "#A6ADC8",
+	TextMuted:  "#6C7086",
+	Border:     "#313244",
+	Overlay:    "#1E1E2E",
+	Background: "#1E1E2E",
+}
+
+// Monochrome drops every accent hue down to shades of gray, for terminals
+// that can't render color (see Load's termenv.Ascii detection) or users who
+// just prefer it.
+var Monochrome = Theme{
+	Name:       "monochrome",
+	Accent:     "#FFFFFF",
+	Success:    "#FFFFFF",
+	Danger:     "#FFFFFF",
+	Warning:    "#FFFFFF",
+	Info:       "#FFFFFF",
+	TextBright: "#FFFFFF",
+	TextDim:    "#AAAAAA",
+	TextMuted:  "#888888",
+	Border:     "#666666",
+	Overlay:    "#333333",
+	Background: "#000000",
+}
+
+// All returns every built-in theme, in the order the theme-picker modal
+// lists them.
+func All() []Theme {
+	return []Theme{Dracula, Nord, SolarizedDark, SolarizedLight, Gruvbox, Catppuccin, Monochrome}
+}
+
+// ByName looks up a built-in theme by its Name, case-sensitively matching
+// the lowercase-kebab names above.
+func ByName(name string) (Theme, bool) {
+	for _, t := range All() {
+		if t.Name == name {
+			return t, true
+		}
+	}
+	return Theme{}, false
+}
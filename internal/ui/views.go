@@ -2,8 +2,10 @@ package ui
 
 import (
 	"fmt"
+	"strings"
 
 	"ctchen222/chronoflow/internal/domain"
+	"ctchen222/chronoflow/internal/ui/theme"
 
 	"github.com/charmbracelet/lipgloss"
 )
@@ -12,11 +14,34 @@ import (
 type ViewRenderer struct {
 	width  int
 	height int
+	theme  theme.Theme
 }
 
-// NewViewRenderer creates a new ViewRenderer
-func NewViewRenderer() *ViewRenderer {
-	return &ViewRenderer{}
+// NewViewRenderer creates a new ViewRenderer that renders with th.
+func NewViewRenderer(th theme.Theme) *ViewRenderer {
+	return &ViewRenderer{theme: th}
+}
+
+// ModalDimensions holds the sizing CalculateModalDimensions derives for the
+// editing modal's title/description inputs and, when a markdown preview is
+// showing, the preview pane beside them.
+type ModalDimensions struct {
+	InputWidth   int
+	ShowPreview  bool
+	PreviewWidth int
+}
+
+// CalculateModalDimensions sizes the editing modal's inputs to the fixed
+// width RenderEditing renders them at, for callers (main.go's window-resize
+// handler) that need to keep the textinput/textarea widgets themselves in
+// sync with what RenderEditing will draw.
+func (v *ViewRenderer) CalculateModalDimensions(showPreview bool) ModalDimensions {
+	const inputWidth = 60
+	dims := ModalDimensions{InputWidth: inputWidth, ShowPreview: showPreview}
+	if showPreview {
+		dims.PreviewWidth = inputWidth
+	}
+	return dims
 }
 
 // SetSize updates the viewport dimensions
@@ -35,29 +60,53 @@ func (v *ViewRenderer) Height() int {
 	return v.height
 }
 
-// RenderMain renders the main viewing state with calendar and todo panels
+// Theme returns the renderer's current theme.
+func (v *ViewRenderer) Theme() theme.Theme {
+	return v.theme
+}
+
+// SetTheme swaps the renderer's theme, taking effect on the next render —
+// used by the live theme-picker modal.
+func (v *ViewRenderer) SetTheme(th theme.Theme) {
+	v.theme = th
+}
+
+// viewsColumnWidthFraction is how much of the main view's width the saved-
+// views column claims, leaving the rest split between calendar and todo.
+const viewsColumnWidthFraction = 0.18
+
+// RenderMain renders the main viewing state with the calendar, todo, and
+// saved-views panels.
 func (v *ViewRenderer) RenderMain(state MainViewState) string {
 	panelHeight := v.height - 1 // reserve 1 line for help bar
-	calendarWidth := int(float64(v.width) * 0.7)
-	todoWidth := v.width - calendarWidth
+	viewsWidth := int(float64(v.width) * viewsColumnWidthFraction)
+	calendarWidth := int(float64(v.width-viewsWidth) * 0.7)
+	todoWidth := v.width - viewsWidth - calendarWidth
 
 	// Inner content dimensions (subtract 2 for border on each side)
 	calInnerW := calendarWidth - 2
 	calInnerH := panelHeight - 2
 	todoInnerW := todoWidth - 2
 	todoInnerH := panelHeight - 2
+	viewsInnerW := viewsWidth - 2
+	viewsInnerH := panelHeight - 2
 
 	// Get content and place it in fixed-size container
 	calContent := lipgloss.Place(calInnerW, calInnerH, lipgloss.Left, lipgloss.Top, state.CalendarView)
 	todoContent := lipgloss.Place(todoInnerW, todoInnerH, lipgloss.Left, lipgloss.Top, state.TodoView)
+	viewsContent := lipgloss.Place(viewsInnerW, viewsInnerH, lipgloss.Left, lipgloss.Top, v.renderViewsColumn(state, viewsInnerW))
 
 	// Border colors based on focus
-	calBorderColor := lipgloss.Color("#444")
-	todoBorderColor := lipgloss.Color("#444")
-	if state.Focus == FocusCalendar {
-		calBorderColor = lipgloss.Color("#7D56F4")
-	} else {
-		todoBorderColor = lipgloss.Color("#7D56F4")
+	calBorderColor := lipgloss.Color(v.theme.Border)
+	todoBorderColor := lipgloss.Color(v.theme.Border)
+	viewsBorderColor := lipgloss.Color(v.theme.Border)
+	switch state.Focus {
+	case FocusCalendar:
+		calBorderColor = lipgloss.Color(v.theme.Accent)
+	case FocusTodo:
+		todoBorderColor = lipgloss.Color(v.theme.Accent)
+	case FocusViews:
+		viewsBorderColor = lipgloss.Color(v.theme.Accent)
 	}
 
 	// Apply borders
@@ -69,24 +118,69 @@ func (v *ViewRenderer) RenderMain(state MainViewState) string {
 		Border(lipgloss.RoundedBorder()).
 		BorderForeground(todoBorderColor).
 		Render(todoContent)
+	viewsView := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(viewsBorderColor).
+		Render(viewsContent)
+
+	return lipgloss.JoinHorizontal(lipgloss.Top, calView, todoView, viewsView)
+}
+
+// renderViewsColumn renders the list of saved views shown in the third
+// main-view column: pinned views first (see ViewService.GetAll), the
+// active view (if any) highlighted, and the cursor marked when the column
+// has focus.
+func (v *ViewRenderer) renderViewsColumn(state MainViewState, width int) string {
+	titleStyle := lipgloss.NewStyle().
+		Bold(true).
+		Foreground(lipgloss.Color(v.theme.TextBright)).
+		Render("Views")
+
+	if len(state.Views) == 0 {
+		empty := lipgloss.NewStyle().
+			Foreground(lipgloss.Color(v.theme.TextMuted)).
+			Italic(true).
+			Render("No saved views")
+		return lipgloss.JoinVertical(lipgloss.Left, titleStyle, "", empty)
+	}
+
+	lines := make([]string, 0, len(state.Views))
+	for i, sv := range state.Views {
+		prefix := "  "
+		style := lipgloss.NewStyle().Foreground(lipgloss.Color(v.theme.TextDim))
+		if state.Focus == FocusViews && i == state.ViewsCursor {
+			prefix = "> "
+			style = style.Bold(true).Foreground(lipgloss.Color(v.theme.Accent))
+		}
+		if sv.UID == state.ActiveViewUID {
+			style = style.Foreground(lipgloss.Color(v.theme.Success))
+		}
+		name := sv.Name
+		if sv.Pinned {
+			name = "★ " + name
+		}
+		lines = append(lines, style.Render(prefix+name))
+	}
 
-	return lipgloss.JoinHorizontal(lipgloss.Top, calView, todoView)
+	return lipgloss.JoinVertical(lipgloss.Left, append([]string{titleStyle, ""}, lines...)...)
 }
 
 // RenderEditing renders the editing modal
 func (v *ViewRenderer) RenderEditing(state EditingState) string {
 	var accentColor, headerIcon string
 	if state.IsNew {
-		accentColor = "#50FA7B" // Green for new
+		accentColor = v.theme.Success // Green for new
 		headerIcon = "+"
 	} else {
-		accentColor = "#8BE9FD" // Cyan for edit
+		accentColor = v.theme.Info // Cyan for edit
 		headerIcon = "~"
 	}
 
 	// Header
 	headerText := "New Todo"
-	if !state.IsNew {
+	if state.SubtaskOf != "" {
+		headerText = "New Subtask"
+	} else if !state.IsNew {
 		headerText = "Edit Todo"
 	}
 	headerStyle := lipgloss.NewStyle().
@@ -94,18 +188,24 @@ func (v *ViewRenderer) RenderEditing(state EditingState) string {
 		Foreground(lipgloss.Color(accentColor)).
 		MarginBottom(1)
 	header := headerStyle.Render(headerIcon + "  " + headerText)
+	if state.SubtaskOf != "" {
+		subtitleStyle := lipgloss.NewStyle().
+			Foreground(lipgloss.Color(v.theme.TextDim)).
+			MarginBottom(1)
+		header = lipgloss.JoinVertical(lipgloss.Left, header, subtitleStyle.Render("Subtask of: "+state.SubtaskOf))
+	}
 
 	// Date
 	dateText := state.Date.Format("Mon, Jan 2, 2006")
 	dateStyle := lipgloss.NewStyle().
-		Foreground(lipgloss.Color("#888")).
+		Foreground(lipgloss.Color(v.theme.TextDim)).
 		MarginBottom(1)
 	date := dateStyle.Render(dateText)
 
 	// Title input with label
 	titleLabelStyle := lipgloss.NewStyle().
-		Foreground(lipgloss.Color("#888"))
-	titleBorderColor := lipgloss.Color("#444")
+		Foreground(lipgloss.Color(v.theme.TextDim))
+	titleBorderColor := lipgloss.Color(v.theme.Border)
 	if state.Focus == FocusTitle {
 		titleBorderColor = lipgloss.Color(accentColor)
 	}
@@ -121,9 +221,9 @@ func (v *ViewRenderer) RenderEditing(state EditingState) string {
 
 	// Description input with label
 	descLabelStyle := lipgloss.NewStyle().
-		Foreground(lipgloss.Color("#888")).
+		Foreground(lipgloss.Color(v.theme.TextDim)).
 		MarginTop(1)
-	descBorderColor := lipgloss.Color("#444")
+	descBorderColor := lipgloss.Color(v.theme.Border)
 	if state.Focus == FocusDesc {
 		descBorderColor = lipgloss.Color(accentColor)
 	}
@@ -140,6 +240,12 @@ func (v *ViewRenderer) RenderEditing(state EditingState) string {
 	// Priority selector
 	prioritySection := v.renderPrioritySelector(state.Priority, accentColor)
 
+	// Due time + reminder selector
+	dueTimeSection := v.renderDueTimeSelector(state, accentColor)
+
+	// Recurrence selector
+	recurrenceSection := v.renderRecurrenceSelector(state, accentColor)
+
 	// Combine all modal content
 	modalContent := lipgloss.JoinVertical(lipgloss.Left,
 		header,
@@ -147,6 +253,8 @@ func (v *ViewRenderer) RenderEditing(state EditingState) string {
 		titleSection,
 		descSection,
 		prioritySection,
+		dueTimeSection,
+		recurrenceSection,
 	)
 
 	// Modal box with background
@@ -162,13 +270,13 @@ func (v *ViewRenderer) RenderEditing(state EditingState) string {
 		lipgloss.Center, lipgloss.Center,
 		modalBox,
 		lipgloss.WithWhitespaceChars(" "),
-		lipgloss.WithWhitespaceForeground(lipgloss.Color("#333")))
+		lipgloss.WithWhitespaceForeground(lipgloss.Color(v.theme.Overlay)))
 }
 
 // renderPrioritySelector renders the priority selection row
 func (v *ViewRenderer) renderPrioritySelector(selected domain.Priority, accentColor string) string {
 	priorityLabelStyle := lipgloss.NewStyle().
-		Foreground(lipgloss.Color("#888")).
+		Foreground(lipgloss.Color(v.theme.TextDim)).
 		MarginTop(1)
 
 	priorityOptions := []struct {
@@ -176,10 +284,10 @@ func (v *ViewRenderer) renderPrioritySelector(selected domain.Priority, accentCo
 		label string
 		color string
 	}{
-		{domain.PriorityNone, "None", "#666"},
-		{domain.PriorityLow, "Low", "#8BE9FD"},
-		{domain.PriorityMedium, "Medium", "#FFB86C"},
-		{domain.PriorityHigh, "High", "#FF6B6B"},
+		{domain.PriorityNone, "None", v.theme.TextMuted},
+		{domain.PriorityLow, "Low", v.theme.Info},
+		{domain.PriorityMedium, "Medium", v.theme.Warning},
+		{domain.PriorityHigh, "High", v.theme.Danger},
 	}
 
 	var priorityItems []string
@@ -202,6 +310,83 @@ func (v *ViewRenderer) renderPrioritySelector(selected domain.Priority, accentCo
 	)
 }
 
+// renderDueTimeSelector renders the "HH:MM" due-time input and, once it has
+// a value, the Ctrl+A reminder-lead-time cycle next to it.
+func (v *ViewRenderer) renderDueTimeSelector(state EditingState, accentColor string) string {
+	labelStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color(v.theme.TextDim)).
+		MarginTop(1)
+
+	inputBorderColor := lipgloss.Color(v.theme.Border)
+	if state.Focus == FocusDueTime {
+		inputBorderColor = lipgloss.Color(accentColor)
+	}
+	inputStyle := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(inputBorderColor).
+		Padding(0, 1).
+		Width(10)
+
+	sections := []string{
+		labelStyle.Render("Due time (optional, HH:MM)"),
+		inputStyle.Render(state.DueTimeView),
+	}
+
+	if state.DueTimeValue != "" {
+		alarmStyle := lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color(accentColor))
+		sections = append(sections,
+			lipgloss.NewStyle().Foreground(lipgloss.Color(v.theme.TextDim)).Render("Reminder (Ctrl+A to cycle): "+alarmStyle.Render(state.AlarmPreset.Label())),
+		)
+	}
+
+	return lipgloss.JoinVertical(lipgloss.Left, sections...)
+}
+
+// renderRecurrenceSelector renders the repeat-preset row, plus a raw RRULE
+// input box when RecurrenceCustom is selected.
+func (v *ViewRenderer) renderRecurrenceSelector(state EditingState, accentColor string) string {
+	labelStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color(v.theme.TextDim)).
+		MarginTop(1)
+	selectedStyle := lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color(accentColor))
+
+	presets := []RecurrencePreset{RecurrenceNone, RecurrenceDaily, RecurrenceWeekdays, RecurrenceWeekly, RecurrenceMonthly, RecurrenceYearly, RecurrenceCustom}
+	var items []string
+	for _, p := range presets {
+		label := p.Label()
+		if p == state.RecurrencePreset {
+			label = "[" + label + "]"
+			items = append(items, selectedStyle.Render(label))
+		} else {
+			items = append(items, " "+label+" ")
+		}
+	}
+	row := lipgloss.JoinHorizontal(lipgloss.Center, items...)
+
+	sections := []string{
+		labelStyle.Render("Repeat (Ctrl+R to cycle)"),
+		row,
+	}
+
+	if state.RecurrencePreset == RecurrenceCustom {
+		inputBorderColor := lipgloss.Color(v.theme.Border)
+		if state.Focus == FocusRecurrence {
+			inputBorderColor = lipgloss.Color(accentColor)
+		}
+		inputStyle := lipgloss.NewStyle().
+			Border(lipgloss.RoundedBorder()).
+			BorderForeground(inputBorderColor).
+			Padding(0, 1).
+			Width(60)
+		sections = append(sections,
+			labelStyle.Render("RRULE (e.g. FREQ=WEEKLY;BYDAY=MO,WE;EXDATE=20260101)"),
+			inputStyle.Render(state.CustomRRuleView),
+		)
+	}
+
+	return lipgloss.JoinVertical(lipgloss.Left, sections...)
+}
+
 // RenderConfirmDelete renders the delete confirmation modal
 func (v *ViewRenderer) RenderConfirmDelete(state DeleteState) string {
 	// Truncate title if too long
@@ -210,7 +395,7 @@ func (v *ViewRenderer) RenderConfirmDelete(state DeleteState) string {
 		title = title[:32] + "..."
 	}
 
-	accentColor := "#FF6B6B" // Red for delete
+	accentColor := v.theme.Danger // Red for delete
 
 	// Header
 	headerStyle := lipgloss.NewStyle().
@@ -221,23 +406,26 @@ func (v *ViewRenderer) RenderConfirmDelete(state DeleteState) string {
 
 	// Todo title being deleted
 	titleStyle := lipgloss.NewStyle().
-		Foreground(lipgloss.Color("#FFFFFF")).
+		Foreground(lipgloss.Color(v.theme.TextBright)).
 		Bold(true).
 		Padding(1, 0)
 	todoTitle := titleStyle.Render("\"" + title + "\"")
 
 	// Warning message
 	warningStyle := lipgloss.NewStyle().
-		Foreground(lipgloss.Color("#888")).
+		Foreground(lipgloss.Color(v.theme.TextDim)).
 		Italic(true)
 	warning := warningStyle.Render("This action cannot be undone.")
 
+	lines := []string{header, todoTitle}
+	if state.Scope != "" {
+		scopeStyle := lipgloss.NewStyle().Foreground(lipgloss.Color(v.theme.Warning))
+		lines = append(lines, scopeStyle.Render("Scope: "+state.Scope))
+	}
+	lines = append(lines, warning)
+
 	// Modal content
-	modalContent := lipgloss.JoinVertical(lipgloss.Center,
-		header,
-		todoTitle,
-		warning,
-	)
+	modalContent := lipgloss.JoinVertical(lipgloss.Center, lines...)
 
 	// Modal box
 	modalBox := lipgloss.NewStyle().
@@ -252,23 +440,89 @@ func (v *ViewRenderer) RenderConfirmDelete(state DeleteState) string {
 		lipgloss.Center, lipgloss.Center,
 		modalBox,
 		lipgloss.WithWhitespaceChars(" "),
-		lipgloss.WithWhitespaceForeground(lipgloss.Color("#333")))
+		lipgloss.WithWhitespaceForeground(lipgloss.Color(v.theme.Overlay)))
+}
+
+// RenderReminderBanner renders a one-line banner for a due-time reminder
+// that just fired, shown above the help bar until it expires. An empty
+// text means no reminder is active and renders nothing.
+func (v *ViewRenderer) RenderReminderBanner(text string) string {
+	if text == "" {
+		return ""
+	}
+	return lipgloss.NewStyle().
+		Bold(true).
+		Foreground(lipgloss.Color(v.theme.TextBright)).
+		Background(lipgloss.Color(v.theme.Warning)).
+		Padding(0, 1).
+		Width(v.width).
+		Render("⏰ " + text)
+}
+
+// RenderNameView renders the "name this saved view" prompt shown after
+// Ctrl+S in the search modal.
+func (v *ViewRenderer) RenderNameView(state NameViewState) string {
+	accentColor := v.theme.Warning
+
+	headerStyle := lipgloss.NewStyle().
+		Bold(true).
+		Foreground(lipgloss.Color(accentColor)).
+		MarginBottom(1)
+	header := headerStyle.Render("★  Save Search as View")
+
+	queryStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color(v.theme.TextDim)).
+		Italic(true)
+	query := queryStyle.Render("\"" + state.Query + "\"")
+
+	inputLabelStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color(v.theme.TextDim))
+	inputStyle := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(lipgloss.Color(accentColor)).
+		Padding(0, 1).
+		Width(30)
+
+	modalContent := lipgloss.JoinVertical(lipgloss.Left,
+		header,
+		query,
+		"",
+		inputLabelStyle.Render("View name"),
+		inputStyle.Render(state.InputView),
+	)
+
+	modalBox := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(lipgloss.Color(accentColor)).
+		Padding(1, 3).
+		Render(modalContent)
+
+	bgHeight := v.height - 1
+	return lipgloss.Place(v.width, bgHeight,
+		lipgloss.Center, lipgloss.Center,
+		modalBox,
+		lipgloss.WithWhitespaceChars(" "),
+		lipgloss.WithWhitespaceForeground(lipgloss.Color(v.theme.Overlay)))
 }
 
 // RenderSearching renders the search modal
 func (v *ViewRenderer) RenderSearching(state SearchState) string {
-	accentColor := "#FFB86C" // Orange for search
+	accentColor := v.theme.Warning // Orange for search
 
 	// Header
 	headerStyle := lipgloss.NewStyle().
 		Bold(true).
 		Foreground(lipgloss.Color(accentColor)).
 		MarginBottom(1)
-	header := headerStyle.Render("/  Search Todos")
+	headerText := "/  Search Todos"
+	if state.CaseSensitive {
+		headerText += "  [Aa]"
+	}
+	header := headerStyle.Render(headerText)
 
 	// Search input
 	inputLabelStyle := lipgloss.NewStyle().
-		Foreground(lipgloss.Color("#888"))
+		Foreground(lipgloss.Color(v.theme.TextDim))
 	inputBorderColor := lipgloss.Color(accentColor)
 	inputStyle := lipgloss.NewStyle().
 		Border(lipgloss.RoundedBorder()).
@@ -303,7 +557,177 @@ func (v *ViewRenderer) RenderSearching(state SearchState) string {
 		lipgloss.Center, lipgloss.Center,
 		modalBox,
 		lipgloss.WithWhitespaceChars(" "),
-		lipgloss.WithWhitespaceForeground(lipgloss.Color("#333")))
+		lipgloss.WithWhitespaceForeground(lipgloss.Color(v.theme.Overlay)))
+}
+
+// RenderCalendarPicker renders the first-run "pick a CalDAV calendar" modal.
+func (v *ViewRenderer) RenderCalendarPicker(state CalendarPickerState) string {
+	accentColor := v.theme.Info // Cyan for CalDAV setup
+
+	headerStyle := lipgloss.NewStyle().
+		Bold(true).
+		Foreground(lipgloss.Color(accentColor)).
+		MarginBottom(1)
+	header := headerStyle.Render("☁  Choose a calendar to sync")
+
+	var body string
+	switch {
+	case state.Err != "":
+		body = lipgloss.NewStyle().Foreground(lipgloss.Color(v.theme.Danger)).Render(state.Err)
+	case len(state.Choices) == 0:
+		body = lipgloss.NewStyle().Foreground(lipgloss.Color(v.theme.TextDim)).Italic(true).Render("No calendars found on this account")
+	default:
+		selectedStyle := lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color(accentColor))
+		var lines []string
+		for i, choice := range state.Choices {
+			prefix := "  "
+			line := choice.DisplayName
+			if i == state.Cursor {
+				prefix = "> "
+				line = selectedStyle.Render(line)
+			}
+			lines = append(lines, prefix+line)
+		}
+		body = lipgloss.JoinVertical(lipgloss.Left, lines...)
+	}
+
+	hintStyle := lipgloss.NewStyle().Foreground(lipgloss.Color(v.theme.TextMuted)).Italic(true).MarginTop(1)
+	hint := hintStyle.Render("Enter to select, restart chronoflow to start syncing")
+
+	modalContent := lipgloss.JoinVertical(lipgloss.Left, header, body, hint)
+
+	modalBox := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(lipgloss.Color(accentColor)).
+		Padding(1, 2).
+		Render(modalContent)
+
+	bgHeight := v.height - 1
+	return lipgloss.Place(v.width, bgHeight,
+		lipgloss.Center, lipgloss.Center,
+		modalBox,
+		lipgloss.WithWhitespaceChars(" "),
+		lipgloss.WithWhitespaceForeground(lipgloss.Color(v.theme.Overlay)))
+}
+
+// RenderLogin renders the first-run CalDAV login modal around formView,
+// the already-rendered body of a login.Model.
+func (v *ViewRenderer) RenderLogin(formView string) string {
+	accentColor := v.theme.Info // Cyan for CalDAV setup, matching RenderCalendarPicker
+
+	headerStyle := lipgloss.NewStyle().
+		Bold(true).
+		Foreground(lipgloss.Color(accentColor)).
+		MarginBottom(1)
+	header := headerStyle.Render("☁  Connect a CalDAV account")
+
+	hintStyle := lipgloss.NewStyle().Foreground(lipgloss.Color(v.theme.TextMuted)).Italic(true).MarginTop(1)
+	hint := hintStyle.Render("Tab to switch fields, Enter to connect, Esc to skip")
+
+	modalContent := lipgloss.JoinVertical(lipgloss.Left, header, formView, hint)
+
+	modalBox := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(lipgloss.Color(accentColor)).
+		Padding(1, 2).
+		Render(modalContent)
+
+	bgHeight := v.height - 1
+	return lipgloss.Place(v.width, bgHeight,
+		lipgloss.Center, lipgloss.Center,
+		modalBox,
+		lipgloss.WithWhitespaceChars(" "),
+		lipgloss.WithWhitespaceForeground(lipgloss.Color(v.theme.Overlay)))
+}
+
+// RenderEditScope renders the "which occurrences does this edit apply to"
+// picker shown when editing an occurrence of a recurring todo.
+func (v *ViewRenderer) RenderEditScope(state EditScopeState) string {
+	accentColor := v.theme.Info
+	headerText := "↻  Edit \"" + state.Title + "\""
+	if state.IsDelete {
+		accentColor = v.theme.Danger
+		headerText = "🗑  Delete \"" + state.Title + "\""
+	}
+
+	headerStyle := lipgloss.NewStyle().
+		Bold(true).
+		Foreground(lipgloss.Color(accentColor)).
+		MarginBottom(1)
+	header := headerStyle.Render(headerText)
+
+	selectedStyle := lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color(accentColor))
+	var lines []string
+	for i, choice := range EditScopeChoices {
+		prefix := "  "
+		line := choice
+		if i == state.Cursor {
+			prefix = "> "
+			line = selectedStyle.Render(line)
+		}
+		lines = append(lines, prefix+line)
+	}
+	body := lipgloss.JoinVertical(lipgloss.Left, lines...)
+
+	modalContent := lipgloss.JoinVertical(lipgloss.Left, header, body)
+
+	modalBox := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(lipgloss.Color(accentColor)).
+		Padding(1, 2).
+		Render(modalContent)
+
+	bgHeight := v.height - 1
+	return lipgloss.Place(v.width, bgHeight,
+		lipgloss.Center, lipgloss.Center,
+		modalBox,
+		lipgloss.WithWhitespaceChars(" "),
+		lipgloss.WithWhitespaceForeground(lipgloss.Color(v.theme.Overlay)))
+}
+
+// RenderThemePicker renders the theme-picker modal, reusing RenderSearching's
+// chrome so the list behaves the same way (Up/Down to navigate, Enter to
+// confirm) while the caller live-applies state.Choices[state.Cursor] to
+// preview it.
+func (v *ViewRenderer) RenderThemePicker(state ThemePickerState) string {
+	accentColor := v.theme.Accent
+
+	headerStyle := lipgloss.NewStyle().
+		Bold(true).
+		Foreground(lipgloss.Color(accentColor)).
+		MarginBottom(1)
+	header := headerStyle.Render("◐  Choose a theme")
+
+	selectedStyle := lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color(accentColor))
+	var lines []string
+	for i, th := range state.Choices {
+		prefix := "  "
+		line := th.Name
+		if i == state.Cursor {
+			prefix = "> "
+			line = selectedStyle.Render(line)
+		}
+		lines = append(lines, prefix+line)
+	}
+	body := lipgloss.JoinVertical(lipgloss.Left, lines...)
+
+	hintStyle := lipgloss.NewStyle().Foreground(lipgloss.Color(v.theme.TextMuted)).Italic(true).MarginTop(1)
+	hint := hintStyle.Render("Up/Down to preview, Enter to keep, Esc to revert")
+
+	modalContent := lipgloss.JoinVertical(lipgloss.Left, header, body, hint)
+
+	modalBox := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(lipgloss.Color(accentColor)).
+		Padding(1, 2).
+		Render(modalContent)
+
+	bgHeight := v.height - 1
+	return lipgloss.Place(v.width, bgHeight,
+		lipgloss.Center, lipgloss.Center,
+		modalBox,
+		lipgloss.WithWhitespaceChars(" "),
+		lipgloss.WithWhitespaceForeground(lipgloss.Color(v.theme.Overlay)))
 }
 
 // renderSearchResults renders the search results list
@@ -311,12 +735,12 @@ func (v *ViewRenderer) renderSearchResults(state SearchState, accentColor string
 	if len(state.Results) == 0 {
 		if state.InputValue == "" {
 			return lipgloss.NewStyle().
-				Foreground(lipgloss.Color("#666")).
+				Foreground(lipgloss.Color(v.theme.TextMuted)).
 				Italic(true).
 				Render("Type to search...")
 		}
 		return lipgloss.NewStyle().
-			Foreground(lipgloss.Color("#666")).
+			Foreground(lipgloss.Color(v.theme.TextMuted)).
 			Italic(true).
 			Render("No results found")
 	}
@@ -334,7 +758,7 @@ func (v *ViewRenderer) renderSearchResults(state SearchState, accentColor string
 
 	for i := start; i < end; i++ {
 		r := state.Results[i]
-		dateStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#7D56F4"))
+		dateStyle := lipgloss.NewStyle().Foreground(lipgloss.Color(v.theme.Accent))
 		titleStyle := lipgloss.NewStyle()
 
 		prefix := "  "
@@ -347,15 +771,15 @@ func (v *ViewRenderer) renderSearchResults(state SearchState, accentColor string
 		status := "☐"
 		if r.Todo.Complete {
 			status = "☑"
-			titleStyle = titleStyle.Foreground(lipgloss.Color("#666"))
+			titleStyle = titleStyle.Foreground(lipgloss.Color(v.theme.TextMuted))
 		}
 
-		line := prefix + dateStyle.Render(r.DateKey) + " " + status + " " + titleStyle.Render(r.Todo.Title)
+		line := prefix + dateStyle.Render(r.DateKey) + " " + status + " " + renderHighlightedTitle(r.Todo.Title, r.MatchPositions, titleStyle)
 		resultLines = append(resultLines, line)
 	}
 
 	resultsHeader := lipgloss.NewStyle().
-		Foreground(lipgloss.Color("#888")).
+		Foreground(lipgloss.Color(v.theme.TextDim)).
 		MarginTop(1).
 		Render(fmt.Sprintf("Results (%d found)", len(state.Results)))
 
@@ -363,30 +787,67 @@ func (v *ViewRenderer) renderSearchResults(state SearchState, accentColor string
 		append([]string{resultsHeader}, resultLines...)...)
 }
 
+// renderHighlightedTitle renders title with base, bolding and underlining
+// each rune position positions marks as matched by the active fuzzy search
+// query (see search.FuzzyMatch).
+func renderHighlightedTitle(title string, positions []int, base lipgloss.Style) string {
+	if len(positions) == 0 {
+		return base.Render(title)
+	}
+
+	matched := make(map[int]bool, len(positions))
+	for _, p := range positions {
+		matched[p] = true
+	}
+
+	matchStyle := base.Bold(true).Underline(true)
+
+	var b strings.Builder
+	for i, r := range []rune(title) {
+		if matched[i] {
+			b.WriteString(matchStyle.Render(string(r)))
+		} else {
+			b.WriteString(base.Render(string(r)))
+		}
+	}
+	return b.String()
+}
+
 // RenderHelpBar renders the help bar at the bottom
 func (v *ViewRenderer) RenderHelpBar(state AppState, focus AppFocus) string {
 	keyStyle := lipgloss.NewStyle().
-		Foreground(lipgloss.Color("#7D56F4")).
+		Foreground(lipgloss.Color(v.theme.Accent)).
 		Bold(true)
 	descStyle := lipgloss.NewStyle().
-		Foreground(lipgloss.Color("#666"))
+		Foreground(lipgloss.Color(v.theme.TextMuted))
 	sepStyle := lipgloss.NewStyle().
-		Foreground(lipgloss.Color("#444"))
+		Foreground(lipgloss.Color(v.theme.Border))
 
 	sep := sepStyle.Render(" │ ")
 
 	var keys string
 	switch state {
 	case StateViewing:
-		if focus == FocusCalendar {
+		switch focus {
+		case FocusCalendar:
 			keys = keyStyle.Render("h/j/k/l") + descStyle.Render(" nav") + sep +
 				keyStyle.Render("b/n") + descStyle.Render(" month") + sep +
 				keyStyle.Render("w") + descStyle.Render(" week") + sep +
 				keyStyle.Render("t") + descStyle.Render(" today") + sep +
 				keyStyle.Render("/") + descStyle.Render(" search") + sep +
+				keyStyle.Render("H") + descStyle.Render(" habits") + sep +
+				keyStyle.Render("T") + descStyle.Render(" theme") + sep +
 				keyStyle.Render("Tab") + descStyle.Render(" todos") + sep +
 				keyStyle.Render("q") + descStyle.Render(" quit")
-		} else {
+		case FocusViews:
+			keys = keyStyle.Render("j/k") + descStyle.Render(" nav") + sep +
+				keyStyle.Render("Enter") + descStyle.Render(" jump") + sep +
+				keyStyle.Render("r") + descStyle.Render(" rename") + sep +
+				keyStyle.Render("p") + descStyle.Render(" pin") + sep +
+				keyStyle.Render("d") + descStyle.Render(" delete") + sep +
+				keyStyle.Render("Tab") + descStyle.Render(" calendar") + sep +
+				keyStyle.Render("q") + descStyle.Render(" quit")
+		default:
 			keys = keyStyle.Render("j/k") + descStyle.Render(" nav") + sep +
 				keyStyle.Render("J/K") + descStyle.Render(" move") + sep +
 				keyStyle.Render("Space") + descStyle.Render(" done") + sep +
@@ -394,25 +855,50 @@ func (v *ViewRenderer) RenderHelpBar(state AppState, focus AppFocus) string {
 				keyStyle.Render("/") + descStyle.Render(" search") + sep +
 				keyStyle.Render("a") + descStyle.Render(" add") + sep +
 				keyStyle.Render("e") + descStyle.Render(" edit") + sep +
+				keyStyle.Render("H") + descStyle.Render(" habits") + sep +
+				keyStyle.Render("T") + descStyle.Render(" theme") + sep +
+				keyStyle.Render("Tab") + descStyle.Render(" views") + sep +
 				keyStyle.Render("q") + descStyle.Render(" quit")
 		}
 	case StateEditing:
 		keys = keyStyle.Render("Tab") + descStyle.Render(" switch field") + sep +
+			keyStyle.Render("Ctrl+R") + descStyle.Render(" repeat") + sep +
 			keyStyle.Render("Enter") + descStyle.Render(" save") + sep +
 			keyStyle.Render("Esc") + descStyle.Render(" cancel")
+	case StateEditScope:
+		keys = keyStyle.Render("Up/Down") + descStyle.Render(" navigate") + sep +
+			keyStyle.Render("Enter") + descStyle.Render(" select") + sep +
+			keyStyle.Render("Esc") + descStyle.Render(" cancel")
 	case StateConfirmingDelete:
 		keys = keyStyle.Render("y/Enter") + descStyle.Render(" confirm") + sep +
 			keyStyle.Render("n/Esc") + descStyle.Render(" cancel")
 	case StateSearching:
 		keys = keyStyle.Render("Up/Down") + descStyle.Render(" navigate") + sep +
 			keyStyle.Render("Enter") + descStyle.Render(" go to") + sep +
+			keyStyle.Render("Ctrl+F") + descStyle.Render(" case-sensitive") + sep +
+			keyStyle.Render("Ctrl+S") + descStyle.Render(" save as view") + sep +
 			keyStyle.Render("Esc") + descStyle.Render(" cancel")
+	case StateNamingView:
+		keys = keyStyle.Render("Enter") + descStyle.Render(" save") + sep +
+			keyStyle.Render("Esc") + descStyle.Render(" cancel")
+	case StateSelectingCalendar:
+		keys = keyStyle.Render("Up/Down") + descStyle.Render(" navigate") + sep +
+			keyStyle.Render("Enter") + descStyle.Render(" select") + sep +
+			keyStyle.Render("q") + descStyle.Render(" quit")
+	case StateLoggingIn:
+		keys = keyStyle.Render("Tab") + descStyle.Render(" switch field") + sep +
+			keyStyle.Render("Enter") + descStyle.Render(" connect") + sep +
+			keyStyle.Render("Esc") + descStyle.Render(" skip")
+	case StateSelectingTheme:
+		keys = keyStyle.Render("Up/Down") + descStyle.Render(" preview") + sep +
+			keyStyle.Render("Enter") + descStyle.Render(" keep") + sep +
+			keyStyle.Render("Esc") + descStyle.Render(" revert")
 	}
 
 	return lipgloss.NewStyle().
 		Width(v.width).
 		Align(lipgloss.Center).
-		Background(lipgloss.Color("#1a1a1a")).
+		Background(lipgloss.Color(v.theme.Background)).
 		Padding(0, 1).
 		Render(keys)
 }
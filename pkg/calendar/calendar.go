@@ -12,15 +12,15 @@ import (
 var (
 	// Style for the highlighted day (cursor) in the calendar.
 	dayHighlight = lipgloss.NewStyle().
-		Background(lipgloss.Color("#7D56F4")).
-		Foreground(lipgloss.Color("#FFFFFF")).
-		Bold(true)
+			Background(lipgloss.Color("#7D56F4")).
+			Foreground(lipgloss.Color("#FFFFFF")).
+			Bold(true)
 
 	// Style for today's date (when not selected).
 	todayStyle = lipgloss.NewStyle().
-		Foreground(lipgloss.Color("#7D56F4")).
-		Bold(true).
-		Underline(true)
+			Foreground(lipgloss.Color("#7D56F4")).
+			Bold(true).
+			Underline(true)
 )
 
 // TodoItem represents a single todo for display in week view
@@ -28,6 +28,15 @@ type TodoItem struct {
 	Title    string
 	Complete bool
 	Priority int // 0=none, 1=low, 2=medium, 3=high
+	// Dirty marks a todo with local edits not yet pushed to its CalDAV
+	// server, shown as a small "~" marker similar to the overdue badge.
+	Dirty bool
+	// ReadOnly marks an entry mirrored from a remote calendar's VEVENTs
+	// rather than a todo: it has no checkbox and can't be toggled.
+	ReadOnly bool
+	// DueTime is the todo's time of day in "15:04" form, empty when it has
+	// none. Agenda view places items by this; month/week view ignore it.
+	DueTime string
 }
 
 // TodoStatus represents the status of todos for a date
@@ -45,6 +54,7 @@ type ViewMode int
 const (
 	MonthView ViewMode = iota
 	WeekView
+	AgendaView
 )
 
 type Model struct {
@@ -53,7 +63,19 @@ type Model struct {
 	width        int
 	height       int
 	todoStatus   map[string]TodoStatus // todo status by date (format: "2006-01-02")
+	spans        []SpanItem            // multi-day todos, rendered as bars
 	viewMode     ViewMode
+	// syncStatus is a short status string (e.g. "synced 10:04", "sync
+	// error") shown in the header when CalDAV sync is active. Empty hides
+	// it entirely.
+	syncStatus string
+	// agendaStart/agendaEnd bound the hourly timeline rendered by agenda
+	// view, in [0, 24]. Set via SetAgendaRange; default is the full day.
+	agendaStart int
+	agendaEnd   int
+	// agendaSummary is a short "peak" annotation shown in agenda view's
+	// header, e.g. "peak 14:00 ×3". Empty hides it entirely.
+	agendaSummary string
 }
 
 func New() *Model {
@@ -63,18 +85,40 @@ func New() *Model {
 		cursor:       now,
 		todoStatus:   make(map[string]TodoStatus),
 		viewMode:     MonthView,
+		agendaStart:  0,
+		agendaEnd:    24,
 	}
 }
 
-// ToggleViewMode switches between month and week view
+// ToggleViewMode cycles month -> week -> agenda -> month.
 func (m *Model) ToggleViewMode() {
-	if m.viewMode == MonthView {
+	switch m.viewMode {
+	case MonthView:
 		m.viewMode = WeekView
-	} else {
+	case WeekView:
+		m.viewMode = AgendaView
+	default:
 		m.viewMode = MonthView
 	}
 }
 
+// SetAgendaRange sets the hour-of-day range ([0, 24]) agenda view's timeline
+// spans. Invalid ranges (start >= end, or outside [0, 24]) are ignored and
+// the previous range (default: the full day) is kept.
+func (m *Model) SetAgendaRange(startHour, endHour int) {
+	if startHour < 0 || endHour > 24 || startHour >= endHour {
+		return
+	}
+	m.agendaStart = startHour
+	m.agendaEnd = endHour
+}
+
+// SetAgendaSummary sets the short "peak" annotation shown in agenda view's
+// header. Pass an empty string to hide it.
+func (m *Model) SetAgendaSummary(summary string) {
+	m.agendaSummary = summary
+}
+
 // GetViewMode returns the current view mode
 func (m *Model) GetViewMode() ViewMode {
 	return m.viewMode
@@ -85,6 +129,26 @@ func (m *Model) SetTodoStatus(status map[string]TodoStatus) {
 	m.todoStatus = status
 }
 
+// SetSpans updates the multi-day todos rendered as bars in week view.
+func (m *Model) SetSpans(spans []SpanItem) {
+	m.spans = spans
+}
+
+// SetSyncStatus sets the CalDAV sync status text shown in the header.
+// Pass an empty string to hide it.
+func (m *Model) SetSyncStatus(status string) {
+	m.syncStatus = status
+}
+
+// headerTitle appends the current sync status (if any) to base, for the
+// month/week view headers to render.
+func (m *Model) headerTitle(base string) string {
+	if m.syncStatus == "" {
+		return base
+	}
+	return base + "  " + m.syncStatus
+}
+
 func (m *Model) SetSize(width, height int) {
 	m.width = width
 	m.height = height
@@ -148,6 +212,10 @@ func (m *Model) View() string {
 		return m.renderWeekView()
 	}
 
+	if m.viewMode == AgendaView {
+		return m.renderAgendaView()
+	}
+
 	// Month view
 	const minCalendarHeight = 20
 	if m.height < minCalendarHeight {
@@ -158,60 +226,38 @@ func (m *Model) View() string {
 			Render("Terminal too small")
 	}
 
-
-
 	var s strings.Builder
 
-
-
 	// --- RENDER AND APPEND, WITH CORRECT NEWLINE MANAGEMENT ---
 
-
-
 	// Main Header (single line) -> requires a newline after
 
 	header := lipgloss.NewStyle().
-
 		Bold(true).
-
 		Foreground(lipgloss.Color("#FAFAFA")).
-
 		Background(lipgloss.Color("#7D56F4")).
-
 		Padding(0, 1).
-
 		Width(m.width).
-
 		Align(lipgloss.Center).
-
-		Render(m.cursor.Format("January 2006"))
+		Render(m.headerTitle(m.cursor.Format("January 2006")))
 
 	s.WriteString(header)
 
 	s.WriteString("\n")
 
-
-
 	// Sub-header (single line) -> requires a newline after
 
 	subHeader := lipgloss.NewStyle().
-
 		Foreground(lipgloss.Color("240")).
-
 		Padding(0, 1).
-
 		Width(m.width).
-
 		Align(lipgloss.Center).
-
 		Render(m.cursor.Format("Monday, Jan 2, 2006"))
 
 	s.WriteString(subHeader)
 
 	s.WriteString("\n")
 
-
-
 	// Weekday headers (single line) -> requires a newline after
 
 	weekdays := []string{"Sun", "Mon", "Tue", "Wed", "Thu", "Fri", "Sat"}
@@ -241,15 +287,10 @@ func (m *Model) View() string {
 	for i, day := range weekdays {
 
 		style := lipgloss.NewStyle().
-
 			Width(cellWidths[i]).
-
 			Align(lipgloss.Center).
-
 			Foreground(lipgloss.Color("240")).
-
 			BorderBottom(true).
-
 			BorderForeground(lipgloss.Color("238"))
 
 		weekdayViews[i] = style.Render(day)
@@ -262,8 +303,6 @@ func (m *Model) View() string {
 
 	s.WriteString("\n")
 
-
-
 	// --- Grid ---
 	// Available height calculation:
 	// Header overhead: main header (1) + subheader (1) + weekday header with border (2) + 3 newlines = 7
@@ -290,8 +329,6 @@ func (m *Model) View() string {
 
 	}
 
-
-
 	firstDay := time.Date(m.selectedDate.Year(), m.selectedDate.Month(), 1, 0, 0, 0, 0, time.UTC)
 
 	daysInMonth := time.Date(m.selectedDate.Year(), m.selectedDate.Month()+1, 0, 0, 0, 0, 0, time.UTC).Day()
@@ -321,8 +358,6 @@ func (m *Model) View() string {
 				style = style.BorderRight(true)
 			}
 
-
-
 			if (i == 0 && j < int(firstDay.Weekday())) || day > daysInMonth {
 
 				rowViews = append(rowViews, style.Render(""))
@@ -383,8 +418,6 @@ func (m *Model) View() string {
 
 	}
 
-
-
 	return s.String()
 
 }
@@ -398,7 +431,7 @@ func (m *Model) renderWeekView() string {
 
 	// Main Header
 	weekEnd := weekStart.AddDate(0, 0, 6)
-	headerText := fmt.Sprintf("Week of %s - %s", weekStart.Format("Jan 2"), weekEnd.Format("Jan 2, 2006"))
+	headerText := m.headerTitle(fmt.Sprintf("Week of %s - %s", weekStart.Format("Jan 2"), weekEnd.Format("Jan 2, 2006")))
 
 	header := lipgloss.NewStyle().
 		Bold(true).
@@ -451,9 +484,40 @@ func (m *Model) renderWeekView() string {
 	s.WriteString(weekdayHeader)
 	s.WriteString("\n")
 
+	// Multi-day todos: clip each to this week, assign lanes with a greedy
+	// interval-scheduling allocator, then render one full-width bar line per
+	// lane above the per-day cells. Single-day todos render below them.
+	weekStartDay := time.Date(weekStart.Year(), weekStart.Month(), weekStart.Day(), 0, 0, 0, 0, time.Local)
+	weekEndDay := time.Date(weekEnd.Year(), weekEnd.Month(), weekEnd.Day(), 0, 0, 0, 0, time.Local)
+	var weekSpans []SpanItem
+	for _, sp := range m.spans {
+		if clipped, ok := sp.clipToWeek(weekStartDay, weekEndDay); ok {
+			weekSpans = append(weekSpans, clipped)
+		}
+	}
+	const maxSpanLanes = 3
+	lanes := assignLanes(weekSpans)
+	laneCount := 0
+	for _, lane := range lanes {
+		if lane+1 > laneCount {
+			laneCount = lane + 1
+		}
+	}
+	if laneCount > maxSpanLanes {
+		laneCount = maxSpanLanes
+	}
+	for lane := 0; lane < laneCount; lane++ {
+		s.WriteString(renderSpanLane(weekStartDay, cellWidths, weekSpans, lanes, lane))
+		s.WriteString("\n")
+	}
+
 	// Calculate available height for the week row
-	// Header overhead: main header (1) + subheader (1) + weekday header with border (2) + 3 newlines = 7
-	availableHeight := m.height - 7
+	// Header overhead: main header (1) + subheader (1) + weekday header with border (2) + 3 newlines = 7,
+	// plus one line per multi-day bar lane rendered above.
+	availableHeight := m.height - 7 - laneCount
+	if availableHeight < 1 {
+		availableHeight = 1
+	}
 
 	// Calculate how many todo items can fit per cell
 	// Reserve 2 lines for day number and margin, rest for todos
@@ -526,14 +590,21 @@ func (m *Model) renderWeekView() string {
 			for j := 0; j < todosToShow && j < len(status.Items); j++ {
 				todo := status.Items[j]
 
-				// Checkbox
+				// Checkbox (remote calendar events are read-only and get
+				// their own glyph instead)
 				checkbox := "☐"
-				if todo.Complete {
+				switch {
+				case todo.ReadOnly:
+					checkbox = "📅"
+				case todo.Complete:
 					checkbox = "☑"
 				}
 
 				// Truncate title to fit
 				title := todo.Title
+				if todo.Dirty {
+					title += " ~"
+				}
 				if len(title) > maxTodoWidth {
 					title = title[:maxTodoWidth-1] + "…"
 				}
@@ -566,3 +637,96 @@ func (m *Model) renderWeekView() string {
 
 	return s.String()
 }
+
+// renderAgendaView renders an hourly timeline of the cursor date, spanning
+// [agendaStart, agendaEnd). Todos without a time of day are grouped into an
+// "All day" block above the timeline instead of a slot.
+func (m *Model) renderAgendaView() string {
+	var s strings.Builder
+
+	headerText := fmt.Sprintf("Agenda — %s", m.cursor.Format("Monday, Jan 2, 2006"))
+	if m.agendaSummary != "" {
+		headerText += "  (" + m.agendaSummary + ")"
+	}
+	headerText = m.headerTitle(headerText)
+	header := lipgloss.NewStyle().
+		Bold(true).
+		Foreground(lipgloss.Color("#FAFAFA")).
+		Background(lipgloss.Color("#7D56F4")).
+		Padding(0, 1).
+		Width(m.width).
+		Align(lipgloss.Center).
+		Render(headerText)
+	s.WriteString(header)
+	s.WriteString("\n")
+
+	dateKey := m.cursor.Format("2006-01-02")
+	status := m.todoStatus[dateKey]
+
+	byHour := make(map[int][]TodoItem)
+	var allDay []TodoItem
+	for _, item := range status.Items {
+		hour, ok := parseDueHour(item.DueTime)
+		if !ok {
+			allDay = append(allDay, item)
+			continue
+		}
+		byHour[hour] = append(byHour[hour], item)
+	}
+
+	allDayLabel := lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("240"))
+	hourLabel := lipgloss.NewStyle().Foreground(lipgloss.Color("240")).Width(6)
+
+	if len(allDay) > 0 {
+		s.WriteString(allDayLabel.Render("All day"))
+		s.WriteString("\n")
+		for _, item := range allDay {
+			s.WriteString("  " + renderAgendaItem(item) + "\n")
+		}
+	}
+
+	for hour := m.agendaStart; hour < m.agendaEnd; hour++ {
+		label := hourLabel.Render(fmt.Sprintf("%02d:00", hour))
+		items := byHour[hour]
+		if len(items) == 0 {
+			s.WriteString(label + "\n")
+			continue
+		}
+		s.WriteString(label + " " + renderAgendaItem(items[0]) + "\n")
+		for _, item := range items[1:] {
+			s.WriteString(strings.Repeat(" ", 7) + renderAgendaItem(item) + "\n")
+		}
+	}
+
+	return s.String()
+}
+
+// parseDueHour extracts the hour from a "15:04"-form DueTime, reporting ok
+// = false for an empty (untimed) or malformed value.
+func parseDueHour(dueTime string) (hour int, ok bool) {
+	if dueTime == "" {
+		return 0, false
+	}
+	t, err := time.Parse("15:04", dueTime)
+	if err != nil {
+		return 0, false
+	}
+	return t.Hour(), true
+}
+
+// renderAgendaItem renders a single agenda line, matching the
+// checkbox/dirty-marker conventions used by week view's cell rendering.
+func renderAgendaItem(item TodoItem) string {
+	checkbox := "☐"
+	switch {
+	case item.ReadOnly:
+		checkbox = "📅"
+	case item.Complete:
+		checkbox = "☑"
+	}
+	title := item.Title
+	if item.Dirty {
+		title += " ~"
+	}
+	return checkbox + " " + title
+}
@@ -0,0 +1,150 @@
+package calendar
+
+import (
+	"sort"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// SpanItem is a todo that covers more than one day, rendered as a single
+// continuous horizontal bar across the day cells it touches rather than a
+// per-day checkbox line.
+type SpanItem struct {
+	Title    string
+	Complete bool
+	Priority int // 0=none, 1=low, 2=medium, 3=high
+	Start    time.Time
+	End      time.Time // inclusive
+}
+
+// clipToWeek returns the portion of the span that falls within
+// [weekStart, weekEnd] (inclusive), and false if it doesn't overlap at all.
+func (s SpanItem) clipToWeek(weekStart, weekEnd time.Time) (SpanItem, bool) {
+	if s.End.Before(weekStart) || s.Start.After(weekEnd) {
+		return SpanItem{}, false
+	}
+	clipped := s
+	if clipped.Start.Before(weekStart) {
+		clipped.Start = weekStart
+	}
+	if clipped.End.After(weekEnd) {
+		clipped.End = weekEnd
+	}
+	return clipped, true
+}
+
+// days returns how many calendar days the span covers (inclusive).
+func (s SpanItem) days() int {
+	return int(s.End.Sub(s.Start).Hours()/24) + 1
+}
+
+// assignLanes places each item into the lowest-numbered free lane using a
+// greedy interval-scheduling allocator: items are considered start date
+// first, then longest span first, and each claims the first lane whose
+// previously-placed item ends before this one starts. The returned slice
+// has one lane index per input item, in input order.
+func assignLanes(items []SpanItem) []int {
+	order := make([]int, len(items))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(a, b int) bool {
+		ia, ib := items[order[a]], items[order[b]]
+		if !ia.Start.Equal(ib.Start) {
+			return ia.Start.Before(ib.Start)
+		}
+		return ia.days() > ib.days()
+	})
+
+	var laneEnds []time.Time // laneEnds[lane] = end date of the last item placed there
+	lanes := make([]int, len(items))
+	for _, idx := range order {
+		item := items[idx]
+		placed := false
+		for lane, end := range laneEnds {
+			if item.Start.After(end) {
+				laneEnds[lane] = item.End
+				lanes[idx] = lane
+				placed = true
+				break
+			}
+		}
+		if !placed {
+			lanes[idx] = len(laneEnds)
+			laneEnds = append(laneEnds, item.End)
+		}
+	}
+	return lanes
+}
+
+// renderSpanLane renders one lane of a week's multi-day bars as a single
+// full-width line: a merged block for each item occupying this lane
+// (Width = the sum of the cellWidths of the days it covers), blank cells
+// elsewhere.
+func renderSpanLane(weekStart time.Time, cellWidths []int, items []SpanItem, lanes []int, lane int) string {
+	var segments []string
+	for day := 0; day < 7; {
+		date := weekStart.AddDate(0, 0, day)
+		idx := spanAt(items, lanes, lane, date)
+		if idx == -1 {
+			segments = append(segments, lipgloss.NewStyle().Width(cellWidths[day]).Render(""))
+			day++
+			continue
+		}
+
+		item := items[idx]
+		span, width := 0, 0
+		for day+span < 7 && !weekStart.AddDate(0, 0, day+span).After(item.End) {
+			width += cellWidths[day+span]
+			span++
+		}
+		segments = append(segments, spanBarStyle(item).Width(width).Render(truncateSpanTitle(item.Title, width)))
+		day += span
+	}
+	return lipgloss.JoinHorizontal(lipgloss.Top, segments...)
+}
+
+// spanAt returns the index into items of the item occupying lane on date,
+// or -1 if the lane is free that day.
+func spanAt(items []SpanItem, lanes []int, lane int, date time.Time) int {
+	for i, it := range items {
+		if lanes[i] != lane {
+			continue
+		}
+		if !date.Before(it.Start) && !date.After(it.End) {
+			return i
+		}
+	}
+	return -1
+}
+
+// spanBarStyle picks the bar's color based on priority and completion,
+// mirroring the coloring convention used for per-day todo cells.
+func spanBarStyle(item SpanItem) lipgloss.Style {
+	fg := lipgloss.Color("#F8F8F2")
+	switch {
+	case item.Complete:
+		fg = lipgloss.Color("#666")
+	case item.Priority == 3:
+		fg = lipgloss.Color("#FF6B6B")
+	case item.Priority == 2:
+		fg = lipgloss.Color("#FFB86C")
+	}
+	return lipgloss.NewStyle().Background(lipgloss.Color("#44475A")).Foreground(fg).Bold(true)
+}
+
+// truncateSpanTitle shortens title to fit width columns, replacing any
+// cut-off tail with an ellipsis.
+func truncateSpanTitle(title string, width int) string {
+	if width <= 0 {
+		return ""
+	}
+	if len(title) <= width {
+		return title
+	}
+	if width == 1 {
+		return "…"
+	}
+	return title[:width-1] + "…"
+}
@@ -0,0 +1,115 @@
+package calendar
+
+import (
+	"testing"
+	"time"
+)
+
+func mustSpanDate(s string) time.Time {
+	t, err := time.ParseInLocation("2006-01-02", s, time.Local)
+	if err != nil {
+		panic(err)
+	}
+	return t
+}
+
+func span(title, start, end string) SpanItem {
+	return SpanItem{Title: title, Start: mustSpanDate(start), End: mustSpanDate(end)}
+}
+
+func TestAssignLanesNonOverlappingShareLane(t *testing.T) {
+	items := []SpanItem{
+		span("A", "2026-03-02", "2026-03-03"),
+		span("B", "2026-03-04", "2026-03-05"),
+	}
+	lanes := assignLanes(items)
+	if lanes[0] != 0 || lanes[1] != 0 {
+		t.Errorf("lanes = %v, want both on lane 0 (no overlap)", lanes)
+	}
+}
+
+func TestAssignLanesOverlappingGetSeparateLanes(t *testing.T) {
+	items := []SpanItem{
+		span("A", "2026-03-02", "2026-03-05"),
+		span("B", "2026-03-03", "2026-03-04"),
+	}
+	lanes := assignLanes(items)
+	if lanes[0] == lanes[1] {
+		t.Errorf("lanes = %v, want distinct lanes for overlapping spans", lanes)
+	}
+}
+
+func TestAssignLanesReusesFreedLane(t *testing.T) {
+	items := []SpanItem{
+		span("A", "2026-03-02", "2026-03-03"), // lane 0
+		span("B", "2026-03-03", "2026-03-06"), // overlaps A on the 3rd -> lane 1
+		span("C", "2026-03-04", "2026-03-04"), // starts after A ends -> reuses lane 0
+	}
+	lanes := assignLanes(items)
+	if lanes[0] != 0 {
+		t.Errorf("lanes[0] = %d, want 0", lanes[0])
+	}
+	if lanes[1] == lanes[0] {
+		t.Errorf("lanes[1] = %d, want different lane from A", lanes[1])
+	}
+	if lanes[2] != lanes[0] {
+		t.Errorf("lanes[2] = %d, want to reuse A's freed lane %d", lanes[2], lanes[0])
+	}
+}
+
+func TestAssignLanesLongerSpanPlacedFirstOnTie(t *testing.T) {
+	items := []SpanItem{
+		span("short", "2026-03-02", "2026-03-02"),
+		span("long", "2026-03-02", "2026-03-06"),
+	}
+	lanes := assignLanes(items)
+	if lanes[1] != 0 {
+		t.Errorf("lanes[long] = %d, want 0 (longer span claims the lowest lane on a start-date tie)", lanes[1])
+	}
+	if lanes[0] == 0 {
+		t.Errorf("lanes[short] = %d, want a lane other than 0", lanes[0])
+	}
+}
+
+func TestClipToWeekOutsideRangeExcluded(t *testing.T) {
+	s := span("A", "2026-02-01", "2026-02-05")
+	weekStart := mustSpanDate("2026-03-01")
+	weekEnd := mustSpanDate("2026-03-07")
+	if _, ok := s.clipToWeek(weekStart, weekEnd); ok {
+		t.Error("clipToWeek: expected no overlap, got one")
+	}
+}
+
+func TestClipToWeekPartialOverlapClips(t *testing.T) {
+	s := span("A", "2026-03-05", "2026-03-12")
+	weekStart := mustSpanDate("2026-03-01")
+	weekEnd := mustSpanDate("2026-03-07")
+	clipped, ok := s.clipToWeek(weekStart, weekEnd)
+	if !ok {
+		t.Fatal("clipToWeek: expected overlap")
+	}
+	if !clipped.Start.Equal(s.Start) {
+		t.Errorf("Start = %v, want unchanged %v", clipped.Start, s.Start)
+	}
+	if !clipped.End.Equal(weekEnd) {
+		t.Errorf("End = %v, want clipped to week end %v", clipped.End, weekEnd)
+	}
+}
+
+func TestTruncateSpanTitle(t *testing.T) {
+	cases := []struct {
+		title string
+		width int
+		want  string
+	}{
+		{"short", 10, "short"},
+		{"a longer title", 6, "a lon…"},
+		{"x", 0, ""},
+		{"ab", 1, "…"},
+	}
+	for _, c := range cases {
+		if got := truncateSpanTitle(c.title, c.width); got != c.want {
+			t.Errorf("truncateSpanTitle(%q, %d) = %q, want %q", c.title, c.width, got, c.want)
+		}
+	}
+}
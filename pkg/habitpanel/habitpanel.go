@@ -0,0 +1,132 @@
+// Package habitpanel renders a Bubble Tea panel showing today's habits
+// grouped by type, mirroring the pkg/todo and pkg/calendar widgets.
+package habitpanel
+
+import (
+	"fmt"
+	"strings"
+
+	"ctchen222/chronoflow/internal/domain"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// Row is a single habit line, with today's completion state and current
+// streak already resolved by the caller (HabitService owns that logic).
+type Row struct {
+	Habit  domain.Habit
+	Done   bool
+	Streak int
+}
+
+// Group buckets rows under a single HabitType heading.
+type Group struct {
+	Type         domain.HabitType
+	Rows         []Row
+	TotalMinutes int
+}
+
+// Model is a Bubble Tea component for browsing and toggling today's habits.
+type Model struct {
+	groups []Group
+	cursor int // index into the flattened row list
+}
+
+// New creates an empty habit panel.
+func New() Model {
+	return Model{}
+}
+
+// SetGroups replaces the displayed groups, clamping the cursor into range.
+func (m *Model) SetGroups(groups []Group) {
+	m.groups = groups
+	if lastIdx := m.rowCount() - 1; m.cursor > lastIdx {
+		m.cursor = lastIdx
+	}
+	if m.cursor < 0 {
+		m.cursor = 0
+	}
+}
+
+func (m Model) rowCount() int {
+	n := 0
+	for _, g := range m.groups {
+		n += len(g.Rows)
+	}
+	return n
+}
+
+// Selected returns the habit under the cursor, or false if there are none.
+func (m Model) Selected() (domain.Habit, bool) {
+	idx := 0
+	for _, g := range m.groups {
+		for _, r := range g.Rows {
+			if idx == m.cursor {
+				return r.Habit, true
+			}
+			idx++
+		}
+	}
+	return domain.Habit{}, false
+}
+
+func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
+	keyMsg, ok := msg.(tea.KeyMsg)
+	if !ok {
+		return m, nil
+	}
+	switch keyMsg.String() {
+	case "up", "k":
+		if m.cursor > 0 {
+			m.cursor--
+		}
+	case "down", "j":
+		if m.cursor < m.rowCount()-1 {
+			m.cursor++
+		}
+	}
+	return m, nil
+}
+
+func (m Model) View() string {
+	if len(m.groups) == 0 {
+		return lipgloss.NewStyle().Foreground(lipgloss.Color("#888")).Render("No habits scheduled today")
+	}
+
+	headingStyle := lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("#7D56F4"))
+	doneStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#50FA7B"))
+	selectedStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#FAFAFA")).Bold(true)
+	streakStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#888"))
+
+	var b strings.Builder
+	idx := 0
+	for gi, g := range m.groups {
+		if gi > 0 {
+			b.WriteString("\n")
+		}
+		fmt.Fprintf(&b, "%s (%dm)\n", headingStyle.Render(g.Type.String()), g.TotalMinutes)
+
+		for _, r := range g.Rows {
+			checkbox := "☐"
+			if r.Done {
+				checkbox = "☑"
+			}
+			line := fmt.Sprintf("%s %s", checkbox, r.Habit.Label)
+			if r.Streak > 0 {
+				line += " " + streakStyle.Render(fmt.Sprintf("(streak %d)", r.Streak))
+			}
+			if r.Done {
+				line = doneStyle.Render(line)
+			}
+			if idx == m.cursor {
+				line = selectedStyle.Render("> ") + line
+			} else {
+				line = "  " + line
+			}
+			b.WriteString(line + "\n")
+			idx++
+		}
+	}
+	return strings.TrimRight(b.String(), "\n")
+}
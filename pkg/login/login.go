@@ -0,0 +1,131 @@
+// Package login renders a Bubble Tea form collecting the URL, username and
+// password for a remote sync account, mirroring the pkg/todo, pkg/calendar
+// and pkg/habitpanel widgets. The password lives only in this model until
+// the caller hands it to internal/secrets; it is never written to
+// chronoflow's own JSON config.
+package login
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// field indexes which input currently has focus.
+type field int
+
+const (
+	fieldURL field = iota
+	fieldUsername
+	fieldPassword
+	fieldCount
+)
+
+// Model is a Bubble Tea component for a URL/username/password login form.
+type Model struct {
+	urlInput  textinput.Model
+	userInput textinput.Model
+	passInput textinput.Model
+	focus     field
+	err       string
+}
+
+// New creates a login form, pre-filled with url and username (e.g. from a
+// previously saved, still-incomplete CalDAV config) so the user only has
+// to type the password.
+func New(url, username string) Model {
+	urlInput := textinput.New()
+	urlInput.Placeholder = "https://caldav.example.com/"
+	urlInput.SetValue(url)
+	urlInput.CharLimit = 256
+	urlInput.Width = 40
+	urlInput.Focus()
+
+	userInput := textinput.New()
+	userInput.Placeholder = "username"
+	userInput.SetValue(username)
+	userInput.CharLimit = 128
+	userInput.Width = 40
+
+	passInput := textinput.New()
+	passInput.Placeholder = "password"
+	passInput.CharLimit = 256
+	passInput.Width = 40
+	passInput.EchoMode = textinput.EchoPassword
+	passInput.EchoCharacter = '•'
+
+	return Model{urlInput: urlInput, userInput: userInput, passInput: passInput}
+}
+
+// Values returns the current contents of the three fields.
+func (m Model) Values() (url, username, password string) {
+	return m.urlInput.Value(), m.userInput.Value(), m.passInput.Value()
+}
+
+// Ready reports whether all three fields are non-empty.
+func (m Model) Ready() bool {
+	url, username, password := m.Values()
+	return url != "" && username != "" && password != ""
+}
+
+// SetError shows msg above the form, e.g. after a failed connection
+// attempt, clearing it on the next SetError("").
+func (m *Model) SetError(msg string) {
+	m.err = msg
+}
+
+func (m *Model) focusCurrent() {
+	m.urlInput.Blur()
+	m.userInput.Blur()
+	m.passInput.Blur()
+	switch m.focus {
+	case fieldURL:
+		m.urlInput.Focus()
+	case fieldUsername:
+		m.userInput.Focus()
+	case fieldPassword:
+		m.passInput.Focus()
+	}
+}
+
+func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
+	if keyMsg, ok := msg.(tea.KeyMsg); ok {
+		switch keyMsg.String() {
+		case "tab", "down":
+			m.focus = (m.focus + 1) % fieldCount
+			m.focusCurrent()
+			return m, nil
+		case "shift+tab", "up":
+			m.focus = (m.focus - 1 + fieldCount) % fieldCount
+			m.focusCurrent()
+			return m, nil
+		}
+	}
+
+	var cmd tea.Cmd
+	switch m.focus {
+	case fieldURL:
+		m.urlInput, cmd = m.urlInput.Update(msg)
+	case fieldUsername:
+		m.userInput, cmd = m.userInput.Update(msg)
+	case fieldPassword:
+		m.passInput, cmd = m.passInput.Update(msg)
+	}
+	return m, cmd
+}
+
+func (m Model) View() string {
+	labelStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#888")).Width(10)
+
+	rows := []string{
+		labelStyle.Render("URL") + m.urlInput.View(),
+		labelStyle.Render("Username") + m.userInput.View(),
+		labelStyle.Render("Password") + m.passInput.View(),
+	}
+	if m.err != "" {
+		rows = append(rows, "", lipgloss.NewStyle().Foreground(lipgloss.Color("#FF6B6B")).Render(m.err))
+	}
+	return strings.Join(rows, "\n")
+}
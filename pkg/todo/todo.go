@@ -15,12 +15,14 @@ var (
 
 // Stats holds statistics about todos
 type Stats struct {
-	TotalAll       int    // total todos across all dates
-	CompletedAll   int    // completed todos across all dates
-	OverdueAll     int    // overdue todos across all dates
-	TotalPeriod    int    // todos for current period (week/month)
-	CompletedPeriod int   // completed for current period
-	PeriodLabel    string // "This Week" or "This Month"
+	TotalAll             int    // total todos across all dates
+	CompletedAll         int    // completed todos across all dates
+	OverdueAll           int    // overdue todos across all dates
+	TotalPeriod          int    // todos for current period (week/month)
+	CompletedPeriod      int    // completed for current period
+	OverduePeriod        int    // overdue for current period
+	PeriodLabel          string // "This Week" or "This Month"
+	HabitsPeriodComplete int    // habit completions within the current period
 }
 
 type Model struct {
@@ -111,6 +113,10 @@ func (m Model) View() string {
 		if m.stats.OverdueAll > 0 {
 			statsText += "  " + overdueStyle.Render(fmt.Sprintf("Overdue: %d", m.stats.OverdueAll))
 		}
+		if m.stats.HabitsPeriodComplete > 0 {
+			habitStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#8BE9FD"))
+			statsText += "  " + habitStyle.Render(fmt.Sprintf("Habits: %d", m.stats.HabitsPeriodComplete))
+		}
 
 		content = lipgloss.JoinVertical(lipgloss.Left,
 			content,